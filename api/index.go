@@ -18,6 +18,11 @@ var (
 	Router *gin.Engine
 )
 
+// init builds the router for the serverless deployment. No gameweek
+// scheduler runs here: schedule an external cron (e.g. every few minutes) to
+// GET /api/v1/cron/gameweeks/rotate with "Authorization: Bearer
+// $CRON_SECRET". Handlers that depend on the XI also rotate their auction on
+// demand.
 func init() {
 	gin.SetMode(gin.ReleaseMode)
 
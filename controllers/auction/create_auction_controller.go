@@ -40,6 +40,8 @@ func CreateAuctionController(logger *zap.Logger, db *mongo.Database) gin.Handler
 			"auction_date":   request.AuctionDate,
 			"created_by":     email,
 			"is_ipl_auction": request.IsIPLAuction,
+			"series_id":      request.SeriesID,
+			"series_name":    request.SeriesName,
 			"joined_by":      []string{},
 			"created_at":     time.Now(),
 			"updated_at":     time.Now(),
@@ -47,6 +47,8 @@ func UpdateAuctionController(logger *zap.Logger, db *mongo.Database) gin.Handler
 				"auction_image":  request.AuctionImage,
 				"auction_date":   request.AuctionDate,
 				"is_ipl_auction": request.IsIPLAuction,
+				"series_id":      request.SeriesID,
+				"series_name":    request.SeriesName,
 				"updated_at":     time.Now(),
 			},
 		}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/lineup"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetElevenRulesController returns the playing XI selection rules of an
+// auction, falling back to the defaults for anything not configured: the
+// IPL squad rules for IPL auctions, an unrestricted XI otherwise.
+func GetElevenRulesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		rules, err := loadElevenRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch eleven rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Playing XI rules fetched successfully",
+			"rules":   rules,
+		})
+	}
+}
+
+// loadElevenRules reads the eleven_rules of an auction on top of the default
+// rules, so fields the auction never set keep their default value. IPL
+// auctions default to the IPL squad rules; other auctions only get the
+// rules an admin configured.
+func loadElevenRules(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (lineup.Rules, error) {
+	var doc struct {
+		IsIPLAuction bool     `bson:"is_ipl_auction"`
+		ElevenRules  bson.Raw `bson:"eleven_rules"`
+	}
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx,
+		bson.M{"_id": auctionID},
+		options.FindOne().SetProjection(bson.M{"eleven_rules": 1, "is_ipl_auction": 1}),
+	).Decode(&doc)
+	if err != nil {
+		return lineup.Rules{}, err
+	}
+
+	rules := lineup.ClubRules()
+	if doc.IsIPLAuction {
+		rules = lineup.DefaultRules()
+	}
+	if doc.ElevenRules != nil {
+		err = bson.Unmarshal(doc.ElevenRules, &rules)
+	}
+	return rules, err
+}
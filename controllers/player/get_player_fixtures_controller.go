@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/fantasy"
+	"cric-auction-monolith/services/franchise"
+	"cric-auction-monolith/services/gameweek"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// playerFixtures is how many matches a squad player's IPL team plays in a
+// gameweek.
+type playerFixtures struct {
+	PlayerID   primitive.ObjectID `json:"player_id"`
+	PlayerName string             `json:"player_name"`
+	IPLTeam    string             `json:"ipl_team"`
+	Fixtures   int                `json:"fixtures"`
+	Matches    []fixtureOpponent  `json:"matches"`
+}
+
+type fixtureOpponent struct {
+	Opponent  string    `json:"opponent"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// GetPlayerFixturesController counts the fixtures of every player in a
+// team's squad for a gameweek, the next open one unless a number is given.
+func GetPlayerFixturesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			TeamID   primitive.ObjectID `json:"team_id" binding:"required"`
+			Gameweek int                `json:"gameweek"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Error("failed to bind player fixtures request", zap.Any(constants.Err, err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		var team models.Team
+		err := db.Collection(constants.TeamCollection).FindOne(ctx, bson.M{"_id": req.TeamID}).Decode(&team)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch team", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		var gw *models.Gameweek
+		if req.Gameweek > 0 {
+			var found models.Gameweek
+			err = db.Collection(constants.GameweekCollection).FindOne(ctx,
+				bson.M{"auction_id": team.AuctionId, "number": req.Gameweek},
+			).Decode(&found)
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Gameweek not found"})
+				return
+			}
+			gw = &found
+		} else {
+			gw, err = gameweek.NextOpen(ctx, db, team.AuctionId)
+		}
+		if err != nil {
+			logger.Error("failed to fetch gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+		if gw == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No open gameweek scheduled"})
+			return
+		}
+
+		teams, err := franchise.ForAuction(ctx, db, team.AuctionId)
+		if err != nil {
+			logger.Error("failed to fetch franchises", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{"_id": bson.M{"$in": team.Squad}})
+		if err != nil {
+			logger.Error("failed to fetch squad", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+		var squad []models.Player
+		if err := cursor.All(ctx, &squad); err != nil {
+			logger.Error("failed to decode squad", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		players := make([]playerFixtures, 0, len(squad))
+		for _, p := range squad {
+			pf := playerFixtures{
+				PlayerID:   p.Id,
+				PlayerName: p.PlayerName,
+				IPLTeam:    p.IPLTeam,
+				Matches:    gameweekMatches(gw, teams, p.IPLTeam),
+			}
+			pf.Fixtures = len(pf.Matches)
+			players = append(players, pf)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Player fixtures fetched successfully",
+			"gameweek": gw.Number,
+			"players":  players,
+		})
+	}
+}
+
+// gameweekMatches returns the matches an IPL team plays in a gameweek.
+func gameweekMatches(gw *models.Gameweek, teams fantasy.TeamAliases, iplTeam string) []fixtureOpponent {
+	matches := []fixtureOpponent{}
+	for _, f := range gw.Fixtures {
+		for i, t := range f.Teams {
+			if !teams.Match(t, iplTeam) {
+				continue
+			}
+			m := fixtureOpponent{StartTime: f.StartTime}
+			if len(f.Teams) == 2 {
+				m.Opponent = f.Teams[1-i]
+			}
+			matches = append(matches, m)
+			break
+		}
+	}
+	return matches
+}
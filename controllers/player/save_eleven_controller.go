@@ -4,20 +4,23 @@ import (
 	"context"
 	"cric-auction-monolith/core/constants"
 	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/gameweek"
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
 func SaveElevenController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			SquadIDs  []primitive.ObjectID `json:"squad" binding:"required"`
+			TeamID    primitive.ObjectID   `json:"team_id"`
+			SquadIDs  []primitive.ObjectID `json:"squad"`
 			PlayerIDs []primitive.ObjectID `json:"player_ids" binding:"required"`
 		}
 
@@ -27,40 +30,72 @@ func SaveElevenController(logger *zap.Logger, db *mongo.Database) gin.HandlerFun
 			return
 		}
 
-		if len(req.PlayerIDs) != 11 {
-			c.JSON(400, gin.H{"error": "Exactly 11 player IDs required"})
-			return
+		// Older clients send the squad instead of the team ID; the team is
+		// the one that owns the first squad player.
+		filter := bson.M{"_id": req.TeamID}
+		if req.TeamID.IsZero() {
+			if len(req.SquadIDs) == 0 {
+				c.JSON(400, gin.H{"error": "team_id is required"})
+				return
+			}
+			filter = bson.M{"squad": req.SquadIDs[0]}
 		}
 
-		if len(req.SquadIDs) < 11 {
-			c.JSON(400, gin.H{"error": "Squad must have at least 11 players"})
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		var team models.Team
+		err := db.Collection(constants.TeamCollection).FindOne(ctx, filter).Decode(&team)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(404, gin.H{"error": "Team not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("team fetch failed", zap.Error(err))
+			c.JSON(500, gin.H{"error": "DB error"})
 			return
 		}
 
-		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
-		defer cancel()
+		check, err := checkEleven(ctx, db, team, req.PlayerIDs)
+		if err != nil {
+			logger.Error("failed to validate eleven", zap.Error(err))
+			c.JSON(500, gin.H{"error": "DB error"})
+			return
+		}
+		if len(check.Violations) > 0 {
+			c.JSON(422, gin.H{
+				"error":      check.Violations[0].Message,
+				"violations": check.Violations,
+				"counts":     check.Counts,
+			})
+			return
+		}
 
-		cursor, err := db.Collection(constants.PlayerCollection).Find(
-			ctx,
-			bson.M{"_id": bson.M{"$in": req.SquadIDs}},
-			options.Find().SetProjection(bson.M{"match": 1}),
-		)
+		// The saved XI plays from the next gameweek, so edits close at its
+		// deadline. Start any gameweek that is due first so the deadline
+		// checked is the right one.
+		now := time.Now()
+		if _, err := gameweek.RotateAuction(ctx, db, team.AuctionId, now); err != nil {
+			logger.Error("failed to rotate due gameweeks", zap.Error(err))
+		}
+		locked, gw, err := gameweek.Locked(ctx, db, team.AuctionId, now)
 		if err != nil {
-			logger.Error("player fetch failed", zap.Error(err))
+			logger.Error("failed to check gameweek deadline", zap.Error(err))
 			c.JSON(500, gin.H{"error": "DB error"})
 			return
 		}
-		defer cursor.Close(ctx)
+		if locked {
+			c.JSON(403, gin.H{
+				"error":      fmt.Sprintf("Playing XI is locked until gameweek %d starts", gw.Number),
+				"gameweek":   gw.Number,
+				"start_time": gw.StartTime,
+			})
+			return
+		}
 
-		playing11MatchIDs := make([]primitive.ObjectID, 0, 11)
-		nonPlaying11MatchIDs := make([]primitive.ObjectID, 0, len(req.SquadIDs)-11)
-		for cursor.Next(ctx) {
-			var player models.Player
-			if err := cursor.Decode(&player); err != nil {
-				logger.Error("failed to decode player", zap.Error(err))
-				c.JSON(500, gin.H{"error": "Failed to decode player"})
-				return
-			}
+		playing11MatchIDs := make([]primitive.ObjectID, 0, len(req.PlayerIDs))
+		nonPlaying11MatchIDs := make([]primitive.ObjectID, 0, len(check.Squad))
+		for _, player := range check.Squad {
 			if slices.Contains(req.PlayerIDs, player.Id) {
 				playing11MatchIDs = append(playing11MatchIDs, player.Match)
 			} else {
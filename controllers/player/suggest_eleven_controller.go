@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/franchise"
+	"cric-auction-monolith/services/gameweek"
+	"cric-auction-monolith/services/lineup"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// leagueStageMatches turns last season's fantasy points into a per-match
+// average for players without recent matches.
+const leagueStageMatches = 14
+
+// SuggestElevenController suggests the XI with the most projected points for
+// the next gameweek. A player's projection is their recent match average
+// times the fixtures their IPL team plays that gameweek. The suggestion
+// respects the auction's XI rules and explains every pick and benching.
+func SuggestElevenController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			TeamID primitive.ObjectID `json:"team_id" binding:"required"`
+			Recent int                `json:"recent"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Error("failed to bind suggest eleven request", zap.Any(constants.Err, err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+		if req.Recent <= 0 {
+			req.Recent = 5
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		var team models.Team
+		err := db.Collection(constants.TeamCollection).FindOne(ctx, bson.M{"_id": req.TeamID}).Decode(&team)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch team", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		rules, err := loadElevenRules(ctx, db, team.AuctionId)
+		if err != nil {
+			logger.Error("failed to fetch eleven rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{"_id": bson.M{"$in": team.Squad}})
+		if err != nil {
+			logger.Error("failed to fetch squad", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+		var squad []models.Player
+		if err := cursor.All(ctx, &squad); err != nil {
+			logger.Error("failed to decode squad", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		// Fixtures per IPL team in the gameweek the saved XI will play.
+		next, err := gameweek.NextOpen(ctx, db, team.AuctionId)
+		if err != nil {
+			logger.Error("failed to fetch next gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+		teams, err := franchise.ForAuction(ctx, db, team.AuctionId)
+		if err != nil {
+			logger.Error("failed to fetch franchises", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		recent, err := recentPoints(ctx, db, team.AuctionId, team.Squad, req.Recent)
+		if err != nil {
+			logger.Error("failed to fetch recent points", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		candidates := make([]lineup.Candidate, 0, len(squad))
+		for _, p := range squad {
+			// Without a scheduled gameweek every team is assumed to play once.
+			n := 1
+			if next != nil {
+				n = len(gameweekMatches(next, teams, p.IPLTeam))
+			}
+			candidates = append(candidates, lineup.Candidate{
+				Player: lineup.Player{
+					ID:       p.Id.Hex(),
+					Name:     p.PlayerName,
+					Role:     p.Role,
+					Overseas: lineup.IsOverseas(p.Country),
+				},
+				Projection: lineup.Project(recent[p.Id], float64(p.PrevFantasyPoints)/leagueStageMatches, n),
+			})
+		}
+
+		picks, violations := lineup.Suggest(rules, candidates)
+
+		xi := make([]string, 0, rules.Size)
+		projected := 0.0
+		for _, p := range picks {
+			if p.Picked {
+				xi = append(xi, p.ID)
+				projected += p.Projection.Points
+			}
+		}
+
+		var gw any
+		if next != nil {
+			gw = next.Number
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"gameweek":         gw,
+			"player_ids":       xi,
+			"projected_points": projected,
+			"picks":            picks,
+			"violations":       violations,
+		})
+	}
+}
+
+// recentPoints returns up to limit of each player's latest real-match
+// points from the points ledger, newest first. Matches are ordered by the
+// gameweek and match slot they were applied to, which recalculating a match
+// does not change.
+func recentPoints(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, playerIDs []primitive.ObjectID, limit int) (map[primitive.ObjectID][]int, error) {
+	cursor, err := db.Collection(constants.LedgerCollection).Find(ctx,
+		bson.M{"auction_id": auctionID, "player_id": bson.M{"$in": playerIDs}, "applied": true},
+		options.Find().
+			SetSort(bson.D{
+				{Key: "gameweek", Value: -1},
+				{Key: "match_index", Value: -1},
+				{Key: "provider_match_id", Value: -1},
+			}).
+			SetProjection(bson.M{"player_id": 1, "calculated_points": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var entries []models.PointsEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	recent := make(map[primitive.ObjectID][]int)
+	for _, e := range entries {
+		if len(recent[e.PlayerID]) < limit {
+			recent[e.PlayerID] = append(recent[e.PlayerID], e.CalculatedPoints)
+		}
+	}
+	return recent, nil
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/lineup"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UpdateElevenRulesController saves the playing XI selection rules of an
+// auction. Rules missing from the request keep their current value.
+func UpdateElevenRulesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Rules     lineup.Rules       `json:"rules"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		// Bind the auction ID first so the current rules can be used as the
+		// base that the request body is decoded over.
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		rules, err := loadElevenRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch eleven rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		request.Rules = rules
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		if err := request.Rules.Check(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+			bson.M{"_id": request.AuctionID},
+			bson.M{"$set": bson.M{
+				"eleven_rules": request.Rules,
+				"updated_at":   time.Now(),
+			}},
+		)
+		if err != nil {
+			logger.Error("failed to update eleven rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update playing XI rules"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Playing XI rules updated successfully",
+			"rules":   request.Rules,
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/lineup"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ValidateElevenController checks a playing XI against the auction's rules
+// without saving it, so the picker can show problems while the user chooses.
+func ValidateElevenController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			TeamID    primitive.ObjectID   `json:"team_id" binding:"required"`
+			PlayerIDs []primitive.ObjectID `json:"player_ids"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Error("failed to bind validate eleven request", zap.Any(constants.Err, err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		var team models.Team
+		err := db.Collection(constants.TeamCollection).FindOne(ctx, bson.M{"_id": req.TeamID}).Decode(&team)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch team", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		check, err := checkEleven(ctx, db, team, req.PlayerIDs)
+		if err != nil {
+			logger.Error("failed to validate eleven", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "DB error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"valid":      len(check.Violations) == 0,
+			"violations": check.Violations,
+			"counts":     check.Counts,
+			"rules":      check.Rules,
+		})
+	}
+}
+
+// elevenCheck is the outcome of checking an XI against the auction's rules.
+type elevenCheck struct {
+	Rules      lineup.Rules
+	Violations []lineup.Violation
+	Counts     lineup.Counts
+	// Squad holds the team's players with their Match documents.
+	Squad []models.Player
+}
+
+// checkEleven loads the team's squad and the auction's XI rules and
+// validates playerIDs against them.
+func checkEleven(ctx context.Context, db *mongo.Database, team models.Team, playerIDs []primitive.ObjectID) (elevenCheck, error) {
+	var check elevenCheck
+
+	rules, err := loadElevenRules(ctx, db, team.AuctionId)
+	if err != nil {
+		return check, err
+	}
+	check.Rules = rules
+
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{"_id": bson.M{"$in": team.Squad}})
+	if err != nil {
+		return check, err
+	}
+	if err := cursor.All(ctx, &check.Squad); err != nil {
+		return check, err
+	}
+
+	squad := make([]lineup.Player, 0, len(check.Squad))
+	for _, p := range check.Squad {
+		squad = append(squad, lineup.Player{
+			ID:       p.Id.Hex(),
+			Name:     p.PlayerName,
+			Role:     p.Role,
+			Overseas: lineup.IsOverseas(p.Country),
+		})
+	}
+	xi := make([]string, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		xi = append(xi, id.Hex())
+	}
+
+	check.Violations, check.Counts = lineup.Validate(rules, squad, xi)
+	return check, nil
+}
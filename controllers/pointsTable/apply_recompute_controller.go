@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/leaderboard"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ApplyRecomputeController applies a pending recompute job. Each changed
+// entry moves the player's earned or benched points by the difference, in
+// the gameweek it originally counted for. The job is claimed first so it
+// is applied once, and refused if any ledger entry changed since the report
+// was made.
+func ApplyRecomputeController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			JobID   primitive.ObjectID `json:"job_id" binding:"required"`
+			Confirm bool               `json:"confirm"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		if !request.Confirm {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true to apply a recompute"})
+			return
+		}
+
+		// Claim the job so a concurrent apply can't add the deltas again.
+		var job models.RecomputeJob
+		err := db.Collection(constants.RecomputeCollection).FindOneAndUpdate(ctx,
+			bson.M{"_id": request.JobID, "status": models.RecomputePending},
+			bson.M{"$set": bson.M{"status": models.RecomputeApplying}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&job)
+		if err == mongo.ErrNoDocuments {
+			err = db.Collection(constants.RecomputeCollection).FindOne(ctx, bson.M{"_id": request.JobID}).Decode(&job)
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Recompute job not found"})
+				return
+			}
+			if err == nil {
+				c.JSON(http.StatusConflict, gin.H{"error": "Recompute job is already " + job.Status})
+				return
+			}
+		}
+		if err != nil {
+			logger.Error("failed to claim recompute job", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		// Hand the job back unless it was applied.
+		applied := false
+		defer func() {
+			if applied {
+				return
+			}
+			// Still release the job when the request timed out.
+			_, err := db.Collection(constants.RecomputeCollection).UpdateOne(context.WithoutCancel(ctx),
+				bson.M{"_id": job.ID, "status": models.RecomputeApplying},
+				bson.M{"$set": bson.M{"status": models.RecomputePending}},
+			)
+			if err != nil {
+				logger.Error("failed to release recompute job", zap.Error(err))
+			}
+		}()
+
+		rotateAuctionOf(ctx, logger, db, job.AuctionID, nil)
+
+		var auction models.Auction
+		err = db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": job.AuctionID}).Decode(&auction)
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		// Refuse stale jobs: every entry must still hold the points the
+		// report was made from.
+		entryIDs := make([]primitive.ObjectID, 0, len(job.Entries))
+		for _, e := range job.Entries {
+			entryIDs = append(entryIDs, e.EntryID)
+		}
+		cursor, err := db.Collection(constants.LedgerCollection).Find(ctx, bson.M{"_id": bson.M{"$in": entryIDs}})
+		if err != nil {
+			logger.Error("failed to fetch points ledger", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		var current []models.PointsEntry
+		if err := cursor.All(ctx, &current); err != nil {
+			logger.Error("failed to decode points ledger", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		byID := make(map[primitive.ObjectID]models.PointsEntry, len(current))
+		for _, e := range current {
+			byID[e.ID] = e
+		}
+		for _, e := range job.Entries {
+			cur, ok := byID[e.EntryID]
+			if !ok || !cur.Applied || cur.Points != e.OldPoints || cur.Gameweek != e.Gameweek {
+				c.JSON(http.StatusConflict, gin.H{"error": "Points changed since the recompute was made, run it again"})
+				return
+			}
+		}
+
+		matchOps := make([]mongo.WriteModel, 0, len(job.Entries))
+		ledgerOps := make([]mongo.WriteModel, 0, len(job.Entries))
+		snapshotOps := make([]mongo.WriteModel, 0, 2*len(job.Entries))
+		now := time.Now()
+		for _, e := range job.Entries {
+			delta := e.NewPoints - e.OldPoints
+
+			field, prevField := "benchedPoints", "prevBenchedPoints"
+			if e.CountedAs == models.CountedEarned {
+				field, prevField = "earnedPoints", "prevEarnedPoints"
+			}
+			inc := bson.M{field: delta, "totalPoints": delta}
+			if e.Gameweek == auction.CurrentGameweek {
+				// Still the live week: the match slot shows the new points.
+				// Applying the points made the slot exist, however high
+				// the index.
+				if e.MatchIndex >= 0 {
+					inc[fmt.Sprintf("matches.%d", e.MatchIndex)] = delta
+				}
+			} else {
+				// A closed week: it is part of the carried-over totals too.
+				inc[prevField] = delta
+				inc["prevTotalPoints"] = delta
+			}
+			matchOps = append(matchOps, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": e.MatchID}).
+				SetUpdate(bson.M{"$inc": inc}))
+
+			// XI snapshots taken since that gameweek closed include the
+			// entry too, so rolling back later keeps the corrected points.
+			elem := "matches.$[m]."
+			arrayFilters := options.ArrayFilters{Filters: []any{bson.M{"m._id": e.MatchID}}}
+			closing := bson.M{elem + field: delta, elem + "totalPoints": delta}
+			if e.MatchIndex >= 0 {
+				closing[fmt.Sprintf("%smatches.%d", elem, e.MatchIndex)] = delta
+			}
+			snapshotOps = append(snapshotOps,
+				mongo.NewUpdateOneModel().
+					SetFilter(bson.M{"auction_id": job.AuctionID, "gameweek": e.Gameweek}).
+					SetUpdate(bson.M{"$inc": closing}).
+					SetArrayFilters(arrayFilters),
+				mongo.NewUpdateManyModel().
+					SetFilter(bson.M{"auction_id": job.AuctionID, "gameweek": bson.M{"$gt": e.Gameweek}}).
+					SetUpdate(bson.M{"$inc": bson.M{
+						elem + field:             delta,
+						elem + "totalPoints":     delta,
+						elem + prevField:         delta,
+						elem + "prevTotalPoints": delta,
+					}}).
+					SetArrayFilters(arrayFilters),
+			)
+
+			ledgerOps = append(ledgerOps, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": e.EntryID}).
+				SetUpdate(bson.M{"$set": bson.M{
+					"points":            e.NewPoints,
+					"calculated_points": e.NewPoints,
+					"applied_at":        now,
+				}}))
+		}
+
+		// The Match documents, XI history, ledger and job status change
+		// together, so a failed apply leaves nothing half done to repeat.
+		err = applyRecomputeWrites(ctx, db, job.ID, c.GetString("email"), now, matchOps, snapshotOps, ledgerOps)
+		if err != nil {
+			logger.Error("failed to apply recompute", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply recompute"})
+			return
+		}
+		applied = true
+
+		warnings := []string{}
+		if err := leaderboard.Rebuild(ctx, db, job.AuctionID); err != nil {
+			logger.Error("failed to rebuild leaderboard history", zap.Error(err))
+			warnings = append(warnings, "Leaderboard history was not rebuilt")
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Recompute applied successfully",
+			"updated":  len(job.Entries),
+			"warnings": warnings,
+		})
+	}
+}
+
+func applyRecomputeWrites(ctx context.Context, db *mongo.Database, jobID primitive.ObjectID, appliedBy string, now time.Time, matchOps, snapshotOps, ledgerOps []mongo.WriteModel) error {
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+		if err := session.StartTransaction(); err != nil {
+			return err
+		}
+		if len(matchOps) > 0 {
+			writes := []struct {
+				collection string
+				ops        []mongo.WriteModel
+			}{
+				{constants.MatchCollection, matchOps},
+				{constants.XISnapshotCollection, snapshotOps},
+				{constants.LedgerCollection, ledgerOps},
+			}
+			for _, w := range writes {
+				if _, err := db.Collection(w.collection).BulkWrite(sc, w.ops); err != nil {
+					session.AbortTransaction(sc)
+					return err
+				}
+			}
+		}
+
+		_, err := db.Collection(constants.RecomputeCollection).UpdateOne(sc,
+			bson.M{"_id": jobID, "status": models.RecomputeApplying},
+			bson.M{"$set": bson.M{
+				"status":     models.RecomputeApplied,
+				"applied_by": appliedBy,
+				"applied_at": now,
+			}},
+		)
+		if err != nil {
+			session.AbortTransaction(sc)
+			return err
+		}
+		return session.CommitTransaction(sc)
+	})
+}
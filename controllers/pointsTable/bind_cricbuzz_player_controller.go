@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// BindCricbuzzPlayerController sets or corrects the Cricbuzz player ID stored on
+// an auction player. A cricbuzz_id of 0 removes the binding so the player goes
+// back to name matching. A Cricbuzz ID can only be bound to one player per
+// auction, so any previous owner of the ID is unbound.
+func BindCricbuzzPlayerController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID  primitive.ObjectID `json:"auction_id" binding:"required"`
+			PlayerID   primitive.ObjectID `json:"player_id" binding:"required"`
+			CricbuzzID int                `json:"cricbuzz_id"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		if request.CricbuzzID < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cricbuzz_id must be >= 0"})
+			return
+		}
+
+		player, err := bindCricbuzzID(ctx, db, request.AuctionID, request.PlayerID, request.CricbuzzID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to bind cricbuzz player", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save Cricbuzz binding"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Cricbuzz binding saved successfully",
+			"player":  player,
+		})
+	}
+}
+
+// bindCricbuzzID stores cricbuzzID on the player, unbinding it from any other
+// player of the auction first. A cricbuzzID of 0 removes the binding.
+func bindCricbuzzID(ctx context.Context, db *mongo.Database, auctionID, playerID primitive.ObjectID, cricbuzzID int) (models.Player, error) {
+	var player models.Player
+	players := db.Collection(constants.PlayerCollection)
+
+	if cricbuzzID > 0 {
+		_, err := players.UpdateMany(ctx,
+			bson.M{
+				"auction_id":  auctionID,
+				"cricbuzz_id": cricbuzzID,
+				"_id":         bson.M{"$ne": playerID},
+			},
+			bson.M{"$unset": bson.M{"cricbuzz_id": ""}},
+		)
+		if err != nil {
+			return player, err
+		}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"cricbuzz_id": cricbuzzID,
+			"updated_at":  time.Now(),
+		},
+	}
+	if cricbuzzID == 0 {
+		update = bson.M{
+			"$unset": bson.M{"cricbuzz_id": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	err := players.FindOneAndUpdate(ctx,
+		bson.M{"_id": playerID, "auction_id": auctionID},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&player)
+	return player, err
+}
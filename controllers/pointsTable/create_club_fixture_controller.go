@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// CreateClubFixtureController adds a single fixture between two teams of an
+// auction to its club tournament.
+func CreateClubFixtureController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Team1ID   primitive.ObjectID `json:"team1_id" binding:"required"`
+			Team2ID   primitive.ObjectID `json:"team2_id" binding:"required"`
+			Round     int                `json:"round" binding:"min=0"`
+			StartTime time.Time          `json:"start_time"`
+			Venue     string             `json:"venue"`
+			Overs     int                `json:"overs" binding:"min=0"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+		if request.Team1ID == request.Team2ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team1_id and team2_id must differ"})
+			return
+		}
+
+		n, err := db.Collection(constants.TeamCollection).CountDocuments(ctx, bson.M{
+			"_id":        bson.M{"$in": []primitive.ObjectID{request.Team1ID, request.Team2ID}},
+			"auction_id": request.AuctionID,
+		})
+		if err != nil {
+			logger.Error("failed to fetch teams", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		if n != 2 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+
+		now := time.Now()
+		fixture := models.ClubFixture{
+			AuctionID: request.AuctionID,
+			Round:     request.Round,
+			Team1ID:   request.Team1ID,
+			Team2ID:   request.Team2ID,
+			StartTime: request.StartTime,
+			Venue:     request.Venue,
+			Overs:     request.Overs,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		res, err := db.Collection(constants.ClubFixtureCollection).InsertOne(ctx, fixture)
+		if err != nil {
+			logger.Error("failed to insert club fixture", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create fixture"})
+			return
+		}
+		fixture.ID = res.InsertedID.(primitive.ObjectID)
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "Fixture created successfully",
+			"fixture": fixture,
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/season"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CreateGameweekController schedules a gameweek for an auction. Without a
+// start_time the gameweek starts with its first fixture, and without a
+// lock_deadline XI edits close when it starts. Without a number it follows
+// the last gameweek of the auction. Fixtures can be typed or picked from the
+// auction's season by fixture_ids.
+func CreateGameweekController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID    primitive.ObjectID   `json:"auction_id" binding:"required"`
+			Number       int                  `json:"number"`
+			StartTime    time.Time            `json:"start_time"`
+			LockDeadline time.Time            `json:"lock_deadline"`
+			Fixtures     []models.Fixture     `json:"fixtures"`
+			FixtureIDs   []primitive.ObjectID `json:"fixture_ids"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		// Season fixtures picked by ID join the typed ones.
+		if len(request.FixtureIDs) > 0 {
+			picked, err := season.Fixtures(ctx, db, request.AuctionID, request.FixtureIDs)
+			if errors.Is(err, season.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Fixture not found"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to fetch fixtures", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+			for _, f := range picked {
+				request.Fixtures = append(request.Fixtures, models.Fixture{
+					Provider:        f.Provider,
+					ProviderMatchID: f.ProviderMatchID,
+					Teams:           f.Teams(),
+					StartTime:       f.StartTime,
+				})
+			}
+		}
+
+		gw := models.Gameweek{
+			AuctionID:    request.AuctionID,
+			Number:       request.Number,
+			StartTime:    request.StartTime,
+			LockDeadline: request.LockDeadline,
+			Fixtures:     request.Fixtures,
+		}
+		if err := normalizeGameweek(&gw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		gameweeks := db.Collection(constants.GameweekCollection)
+		if gw.Number == 0 {
+			var last models.Gameweek
+			err := gameweeks.FindOne(ctx,
+				bson.M{"auction_id": request.AuctionID},
+				options.FindOne().SetSort(bson.D{{Key: "number", Value: -1}}),
+			).Decode(&last)
+			if err != nil && err != mongo.ErrNoDocuments {
+				logger.Error("failed to fetch gameweeks", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+			gw.Number = last.Number + 1
+		}
+
+		count, err := gameweeks.CountDocuments(ctx, bson.M{"auction_id": request.AuctionID, "number": gw.Number})
+		if err != nil {
+			logger.Error("failed to fetch gameweeks", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		if count > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Gameweek number already exists"})
+			return
+		}
+
+		gw.CreatedAt = time.Now()
+		gw.UpdatedAt = gw.CreatedAt
+		res, err := gameweeks.InsertOne(ctx, gw)
+		if err != nil {
+			logger.Error("failed to insert gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create gameweek"})
+			return
+		}
+		gw.ID = res.InsertedID.(primitive.ObjectID)
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message":  "Gameweek created successfully",
+			"gameweek": gw,
+		})
+	}
+}
+
+// normalizeGameweek fills the start time and lock deadline from the fixtures
+// when they are missing and checks that the deadline is not after the start.
+func normalizeGameweek(gw *models.Gameweek) error {
+	if gw.Number < 0 {
+		return errors.New("number must be >= 0")
+	}
+	if gw.Fixtures == nil {
+		gw.Fixtures = []models.Fixture{}
+	}
+
+	var first time.Time
+	for _, f := range gw.Fixtures {
+		if len(f.Teams) != 2 {
+			return errors.New("each fixture needs exactly two teams")
+		}
+		if !f.StartTime.IsZero() && (first.IsZero() || f.StartTime.Before(first)) {
+			first = f.StartTime
+		}
+	}
+
+	if gw.StartTime.IsZero() {
+		gw.StartTime = first
+	}
+	if gw.StartTime.IsZero() {
+		return errors.New("start_time or a fixture start_time is required")
+	}
+	if gw.LockDeadline.IsZero() {
+		gw.LockDeadline = gw.StartTime
+	}
+	if gw.LockDeadline.After(gw.StartTime) {
+		return errors.New("lock_deadline must not be after start_time")
+	}
+	return nil
+}
@@ -1,38 +1,94 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"os"
+	"strconv"
 
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
 	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/provider"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
-func CricbuzzMatchesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
-	client := cricbuzz.NewClient(
-		os.Getenv("CRICBUZZ_API_KEY"),
-		os.Getenv("CRICBUZZ_API_HOST"),
-	)
-
+// CricbuzzMatchesController lists recent completed matches of a series: the
+// series_id query parameter, or the series of the auction_id one. Without
+// either, or for an auction without a series, IPL matches are listed. The
+// series in the recent matches are returned too, to find a series ID.
+func CricbuzzMatchesController(logger *zap.Logger, db *mongo.Database, scores provider.ScoreProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		resp, err := client.FetchRecentMatches()
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		seriesID := 0
+		if q := c.Query("series_id"); q != "" {
+			id, err := strconv.Atoi(q)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series_id"})
+				return
+			}
+			seriesID = id
+		} else if q := c.Query("auction_id"); q != "" {
+			auctionID, err := primitive.ObjectIDFromHex(q)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid auction_id"})
+				return
+			}
+			var auction models.Auction
+			err = db.Collection(constants.AuctionCollection).FindOne(ctx,
+				bson.M{"_id": auctionID},
+				options.FindOne().SetProjection(bson.M{"series_id": 1}),
+			).Decode(&auction)
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to fetch auction", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+			seriesID = auction.SeriesID
+		}
+
+		resp, err := scores.FetchRecentMatches(ctx)
+		if errors.Is(err, cricbuzz.ErrQuotaExhausted) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Cricbuzz API quota exhausted"})
+			return
+		}
 		if err != nil {
 			logger.Error("failed to fetch recent matches from Cricbuzz", zap.Error(err))
 			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch matches from Cricbuzz"})
 			return
 		}
 
-		matches := cricbuzz.FilterIPLMatches(resp)
+		var matches []cricbuzz.SimplifiedMatch
+		if seriesID > 0 {
+			matches = cricbuzz.FilterSeriesMatches(resp, seriesID)
+		} else {
+			matches = cricbuzz.FilterIPLMatches(resp)
+		}
 		if matches == nil {
 			matches = []cricbuzz.SimplifiedMatch{}
 		}
+		series := cricbuzz.ListSeries(resp)
+		if series == nil {
+			series = []cricbuzz.Series{}
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"message": "IPL matches fetched successfully",
-			"matches": matches,
+			"message":   "Matches fetched successfully",
+			"series_id": seriesID,
+			"matches":   matches,
+			"series":    series,
 		})
 	}
 }
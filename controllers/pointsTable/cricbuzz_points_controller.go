@@ -2,14 +2,17 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"cric-auction-monolith/core/constants"
 	"cric-auction-monolith/pkg/models"
 	"cric-auction-monolith/services/cricbuzz"
 	"cric-auction-monolith/services/fantasy"
+	"cric-auction-monolith/services/franchise"
+	"cric-auction-monolith/services/provider"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,18 +21,17 @@ import (
 	"go.uber.org/zap"
 )
 
-func CricbuzzPointsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
-	client := cricbuzz.NewClient(
-		os.Getenv("CRICBUZZ_API_KEY"),
-		os.Getenv("CRICBUZZ_API_HOST"),
-	)
-
+// CricbuzzPointsController scores a match for an auction. The match is
+// picked as a season fixture, which supplies the teams and the Cricbuzz
+// match ID, or given as cricbuzz_match_id with ipl_team1 and ipl_team2.
+func CricbuzzPointsController(logger *zap.Logger, db *mongo.Database, scores provider.ScoreProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var request struct {
-			AuctionID      primitive.ObjectID `json:"auction_id" binding:"required"`
-			CricbuzzMatchID int               `json:"cricbuzz_match_id" binding:"required"`
-			IPLTeam1       string             `json:"ipl_team1" binding:"required"`
-			IPLTeam2       string             `json:"ipl_team2" binding:"required"`
+			AuctionID       primitive.ObjectID `json:"auction_id" binding:"required"`
+			FixtureID       primitive.ObjectID `json:"fixture_id"`
+			CricbuzzMatchID int                `json:"cricbuzz_match_id"`
+			IPLTeam1        string             `json:"ipl_team1"`
+			IPLTeam2        string             `json:"ipl_team2"`
 		}
 
 		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*constants.DBTimeout)
@@ -41,164 +43,283 @@ func CricbuzzPointsController(logger *zap.Logger, db *mongo.Database) gin.Handle
 			return
 		}
 
+		teams, fixture, err := fixtureTeams(ctx, db, request.AuctionID, request.FixtureID, request.IPLTeam1, request.IPLTeam2)
+		if err != nil {
+			fixtureError(c, logger, err)
+			return
+		}
+		if request.CricbuzzMatchID == 0 && fixture != nil && fixture.Provider == scores.Name() {
+			request.CricbuzzMatchID = fixture.ProviderMatchID
+		}
+		if request.CricbuzzMatchID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cricbuzz_match_id is required"})
+			return
+		}
+
 		// 1. Fetch scorecard from Cricbuzz.
-		scorecard, err := client.FetchScorecard(request.CricbuzzMatchID)
+		scorecard, err := scores.FetchScorecard(ctx, request.CricbuzzMatchID)
+		if errors.Is(err, cricbuzz.ErrQuotaExhausted) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Cricbuzz API quota exhausted"})
+			return
+		}
 		if err != nil {
 			logger.Error("failed to fetch scorecard from Cricbuzz", zap.Error(err))
 			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch scorecard from Cricbuzz"})
 			return
 		}
 
-		// 2. Calculate fantasy points from scorecard.
-		fantasyPoints := fantasy.CalculateAllPoints(scorecard)
+		// 2. Keep the raw scorecard so the match can be recalculated later.
+		if err := storeScorecard(ctx, db, request.AuctionID, scores.Name(), request.CricbuzzMatchID, scorecard); err != nil {
+			logger.Warn("failed to store scorecard", zap.Error(err))
+		}
 
-		// 3. Fetch DB players for both IPL teams in this auction.
-		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{
-			"auction_id": request.AuctionID,
-			"ipl_team":   bson.M{"$in": []string{request.IPLTeam1, request.IPLTeam2}},
-		})
+		// 3. Calculate and match points for the auction.
+		result, err := scoreMatch(ctx, logger, db, request.AuctionID, scores.Name(), request.CricbuzzMatchID,
+			teams, scorecard, true)
 		if err != nil {
-			logger.Error("failed to fetch players", zap.Error(err))
+			logger.Error("failed to score match", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var players []models.Player
-		if err = cursor.All(ctx, &players); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Decode error"})
-			return
-		}
+		c.JSON(http.StatusOK, gin.H{
+			"message":            "Points calculated successfully",
+			"points":             result.Points,
+			"unmatched_cricbuzz": result.UnmatchedCricbuzz,
+			"unmatched_db":       result.UnmatchedDB,
+			"ignored_cricbuzz":   result.IgnoredCricbuzz,
+			"reviews_queued":     result.ReviewsQueued,
+			"match":              result.Match,
+		})
+	}
+}
 
-		// 4. Fetch match documents for these players.
-		matchIDs := make([]primitive.ObjectID, 0, len(players))
-		for _, p := range players {
-			if !p.Match.IsZero() {
-				matchIDs = append(matchIDs, p.Match)
-			}
+// pointResult is the points of one auction player for a real match.
+type pointResult struct {
+	MatchID      string                 `json:"match_id"`
+	PlayerID     primitive.ObjectID     `json:"player_id"`
+	PlayerName   string                 `json:"player_name"`
+	CricbuzzName string                 `json:"cricbuzz_name"`
+	Points       int                    `json:"points"`
+	Breakdown    fantasy.PointBreakdown `json:"breakdown"`
+	Confidence   float64                `json:"confidence"`
+	CricbuzzID   int                    `json:"cricbuzz_id"`
+	MatchedByID  bool                   `json:"matched_by_id"`
+}
+
+// matchScore is the outcome of scoring a scorecard for an auction.
+type matchScore struct {
+	Points            []pointResult
+	UnmatchedCricbuzz []string
+	UnmatchedDB       []string
+	IgnoredCricbuzz   []string
+	ReviewsQueued     int
+	Match             fantasy.MatchInfo
+}
+
+// scoreMatch calculates fantasy points for a scorecard with the auction's
+// rules and matches them to the auction players of the given IPL teams, or
+// to all auction players when no teams are given, as for club matches.
+// With persist set, confirmed bindings, review items and the calculated
+// points in the ledger are saved as a side effect.
+func scoreMatch(ctx context.Context, logger *zap.Logger, db *mongo.Database, auctionID primitive.ObjectID, providerName string, cricbuzzMatchID int, iplTeams []string, scorecard *cricbuzz.ScorecardResponse, persist bool) (*matchScore, error) {
+	// 1. Calculate fantasy points from scorecard with the auction's rules.
+	rules, err := loadScoringRules(ctx, db, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	fantasyPoints, matchInfo := fantasy.CalculateMatchPoints(scorecard, rules)
+
+	// 2. Fetch DB players for both IPL teams in this auction.
+	filter := bson.M{"auction_id": auctionID}
+	if len(iplTeams) > 0 {
+		filter["ipl_team"] = bson.M{"$in": iplTeams}
+	}
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var players []models.Player
+	if err = cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+
+	// 3. Fetch match documents for these players.
+	matchIDs := make([]primitive.ObjectID, 0, len(players))
+	for _, p := range players {
+		if !p.Match.IsZero() {
+			matchIDs = append(matchIDs, p.Match)
 		}
+	}
 
-		matchMap := make(map[primitive.ObjectID]primitive.ObjectID) // player match ref -> match doc ID
-		if len(matchIDs) > 0 {
-			mCursor, err := db.Collection(constants.MatchCollection).Find(ctx,
-				bson.M{"_id": bson.M{"$in": matchIDs}},
-			)
-			if err == nil {
-				var matches []models.Match
-				if mCursor.All(ctx, &matches) == nil {
-					for _, m := range matches {
-						matchMap[m.Id] = m.Id
-					}
+	matchMap := make(map[primitive.ObjectID]primitive.ObjectID) // player match ref -> match doc ID
+	if len(matchIDs) > 0 {
+		mCursor, err := db.Collection(constants.MatchCollection).Find(ctx,
+			bson.M{"_id": bson.M{"$in": matchIDs}},
+		)
+		if err == nil {
+			var matches []models.Match
+			if mCursor.All(ctx, &matches) == nil {
+				for _, m := range matches {
+					matchMap[m.Id] = m.Id
 				}
-				mCursor.Close(ctx)
 			}
+			mCursor.Close(ctx)
 		}
+	}
 
-		// 5. Build DB player list for name matching.
-		dbPlayers := make([]fantasy.DBPlayer, len(players))
-		for i, p := range players {
-			matchID := ""
-			if !p.Match.IsZero() {
-				if _, ok := matchMap[p.Match]; ok {
-					matchID = p.Match.Hex()
-				}
-			}
-			dbPlayers[i] = fantasy.DBPlayer{
-				PlayerName: p.PlayerName,
-				IPLTeam:    p.IPLTeam,
-				Role:       p.Role,
-				MatchID:    matchID,
+	// 4. Build DB player list for name matching.
+	dbPlayers := make([]fantasy.DBPlayer, len(players))
+	for i, p := range players {
+		matchID := ""
+		if !p.Match.IsZero() {
+			if _, ok := matchMap[p.Match]; ok {
+				matchID = p.Match.Hex()
 			}
 		}
-
-		// 6. Match Cricbuzz players to DB players and build response.
-		type pointResult struct {
-			MatchID      string                `json:"match_id"`
-			PlayerName   string                `json:"player_name"`
-			CricbuzzName string                `json:"cricbuzz_name"`
-			Points       int                   `json:"points"`
-			Breakdown    fantasy.PointBreakdown `json:"breakdown"`
-			Confidence   float64               `json:"confidence"`
+		dbPlayers[i] = fantasy.DBPlayer{
+			PlayerName: p.PlayerName,
+			IPLTeam:    p.IPLTeam,
+			Role:       p.Role,
+			MatchID:    matchID,
+			CricbuzzID: p.CricbuzzID,
 		}
+	}
 
-		var results []pointResult
-		matchedDB := make(map[int]bool)
-		matchedCB := make(map[string]bool)
-
-		// Determine which team each Cricbuzz player belongs to.
-		teamForPlayer := buildTeamMap(scorecard)
+	// 5. Match Cricbuzz players to DB players. Resolved review decisions
+	// feed back into matching, and the series' franchise registry tells
+	// which scorecard team names are the same team.
+	decisions, err := loadReviewDecisions(ctx, db, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := franchise.ForAuction(ctx, db, auctionID)
+	if err != nil {
+		return nil, err
+	}
 
-		for cbName, pp := range fantasyPoints {
-			cbTeam := teamForPlayer[cbName]
-			match := fantasy.MatchPlayerToDB(cbName, cbTeam, dbPlayers)
-			if match.DBIndex < 0 {
-				continue
-			}
+	var results []pointResult
+	var reviews []models.MatchReview
+	var ignoredCB []string
+	matchedDB := make(map[int]bool)
+	matchedCB := make(map[string]bool)
 
-			dbP := dbPlayers[match.DBIndex]
-			if dbP.MatchID == "" {
-				continue // no match doc in DB
-			}
+	// Determine which team each Cricbuzz player belongs to.
+	teamForPlayer := buildTeamMap(scorecard)
 
-			// Apply duck rule: only for BAT, WK, AR roles.
-			adjustedPoints := pp.Points
-			role := strings.ToUpper(dbP.Role)
-			isBowler := strings.Contains(role, "BOWL") && !strings.Contains(role, "ALL")
-			if isBowler {
-				// Remove duck penalty if it was applied.
-				for _, d := range pp.Breakdown.Details {
-					if strings.Contains(d, "Duck") {
-						adjustedPoints += 2 // undo the -2
-						break
-					}
-				}
-			}
+	for cbName, pp := range fantasyPoints {
+		cbTeam := teamForPlayer[cbName]
 
-			results = append(results, pointResult{
-				MatchID:      dbP.MatchID,
-				PlayerName:   dbP.PlayerName,
-				CricbuzzName: pp.CricbuzzName,
-				Points:       adjustedPoints,
-				Breakdown:    pp.Breakdown,
-				Confidence:   match.Confidence,
-			})
-
-			matchedDB[match.DBIndex] = true
+		var match fantasy.MatchResult
+		decision, decided := decisions.lookup(pp.CricbuzzID, cbName)
+		switch {
+		case decided && decision.Status == models.ReviewIgnored:
+			ignoredCB = append(ignoredCB, cbName)
 			matchedCB[cbName] = true
+			continue
+		case decided && pp.CricbuzzID == 0:
+			// Players without a Cricbuzz ID can't be bound, so the
+			// resolved player is applied by name on every calculation.
+			match = fantasy.MatchResult{DBIndex: playerIndex(players, decision.ResolvedPlayerID), Confidence: 1.0}
+		default:
+			match = fantasy.MatchPlayer(pp.CricbuzzID, cbName, cbTeam, dbPlayers, teams)
 		}
 
-		// Collect unmatched players.
-		var unmatchedCB []string
-		for cbName := range fantasyPoints {
-			if !matchedCB[cbName] {
-				unmatchedCB = append(unmatchedCB, cbName)
+		if match.DBIndex < 0 {
+			if !decided {
+				reviews = append(reviews, models.MatchReview{
+					Kind:         models.ReviewKindUnmatched,
+					CricbuzzID:   pp.CricbuzzID,
+					CricbuzzName: cbName,
+					CricbuzzTeam: cbTeam,
+				})
 			}
+			continue
 		}
 
-		var unmatchedDB []string
-		for i, p := range dbPlayers {
-			if !matchedDB[i] && p.MatchID != "" {
-				unmatchedDB = append(unmatchedDB, p.PlayerName)
-			}
+		if !decided && !match.ByID && match.Confidence < 1.0 {
+			reviews = append(reviews, models.MatchReview{
+				Kind:              models.ReviewKindLowConfidence,
+				CricbuzzID:        pp.CricbuzzID,
+				CricbuzzName:      cbName,
+				CricbuzzTeam:      cbTeam,
+				SuggestedPlayerID: players[match.DBIndex].Id,
+				SuggestedName:     players[match.DBIndex].PlayerName,
+				Confidence:        match.Confidence,
+			})
 		}
 
-		if results == nil {
-			results = []pointResult{}
+		dbP := dbPlayers[match.DBIndex]
+		if dbP.MatchID == "" {
+			continue // no match doc in DB
 		}
-		if unmatchedCB == nil {
-			unmatchedCB = []string{}
+
+		// Bowlers are not penalised for a duck.
+		adjusted := pp.ForRole(dbP.Role)
+
+		results = append(results, pointResult{
+			MatchID:      dbP.MatchID,
+			PlayerID:     players[match.DBIndex].Id,
+			PlayerName:   dbP.PlayerName,
+			CricbuzzName: pp.CricbuzzName,
+			Points:       adjusted.Points,
+			Breakdown:    adjusted.Breakdown,
+			Confidence:   match.Confidence,
+			CricbuzzID:   pp.CricbuzzID,
+			MatchedByID:  match.ByID,
+		})
+
+		matchedDB[match.DBIndex] = true
+		matchedCB[cbName] = true
+	}
+
+	if persist {
+		if err := queueReviews(ctx, db, auctionID, cricbuzzMatchID, reviews); err != nil {
+			logger.Warn("failed to queue match reviews", zap.Error(err))
 		}
-		if unmatchedDB == nil {
-			unmatchedDB = []string{}
+		if err := recordCalculatedPoints(ctx, db, auctionID, providerName, cricbuzzMatchID, iplTeams, results); err != nil {
+			logger.Warn("failed to record calculated points", zap.Error(err))
 		}
+	}
 
-		c.JSON(http.StatusOK, gin.H{
-			"message":            "Points calculated successfully",
-			"points":             results,
-			"unmatched_cricbuzz": unmatchedCB,
-			"unmatched_db":       unmatchedDB,
-		})
+	// Collect unmatched players.
+	var unmatchedCB []string
+	for cbName := range fantasyPoints {
+		if !matchedCB[cbName] {
+			unmatchedCB = append(unmatchedCB, cbName)
+		}
+	}
+
+	var unmatchedDB []string
+	for i, p := range dbPlayers {
+		if !matchedDB[i] && p.MatchID != "" {
+			unmatchedDB = append(unmatchedDB, p.PlayerName)
+		}
+	}
+
+	if results == nil {
+		results = []pointResult{}
+	}
+	if unmatchedCB == nil {
+		unmatchedCB = []string{}
+	}
+	if unmatchedDB == nil {
+		unmatchedDB = []string{}
 	}
+	if ignoredCB == nil {
+		ignoredCB = []string{}
+	}
+
+	return &matchScore{
+		Points:            results,
+		UnmatchedCricbuzz: unmatchedCB,
+		UnmatchedDB:       unmatchedDB,
+		IgnoredCricbuzz:   ignoredCB,
+		ReviewsQueued:     len(reviews),
+		Match:             matchInfo,
+	}, nil
 }
 
 // buildTeamMap creates a map of player name -> team short name from the scorecard.
@@ -217,3 +338,184 @@ func buildTeamMap(sc *cricbuzz.ScorecardResponse) map[string]string {
 	}
 	return m
 }
+
+// reviewDecisions holds the resolved match reviews of an auction, keyed by
+// Cricbuzz ID and by lowercase Cricbuzz name.
+type reviewDecisions struct {
+	byID   map[int]models.MatchReview
+	byName map[string]models.MatchReview
+}
+
+func loadReviewDecisions(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (reviewDecisions, error) {
+	decisions := reviewDecisions{
+		byID:   make(map[int]models.MatchReview),
+		byName: make(map[string]models.MatchReview),
+	}
+
+	cursor, err := db.Collection(constants.ReviewCollection).Find(ctx, bson.M{
+		"auction_id": auctionID,
+		"status":     bson.M{"$ne": models.ReviewPending},
+	})
+	if err != nil {
+		return decisions, err
+	}
+	defer cursor.Close(ctx)
+
+	var resolved []models.MatchReview
+	if err := cursor.All(ctx, &resolved); err != nil {
+		return decisions, err
+	}
+
+	for _, r := range resolved {
+		if r.CricbuzzID > 0 {
+			decisions.byID[r.CricbuzzID] = r
+		} else {
+			decisions.byName[strings.ToLower(r.CricbuzzName)] = r
+		}
+	}
+	return decisions, nil
+}
+
+func (d reviewDecisions) lookup(cricbuzzID int, name string) (models.MatchReview, bool) {
+	if cricbuzzID > 0 {
+		r, ok := d.byID[cricbuzzID]
+		return r, ok
+	}
+	r, ok := d.byName[strings.ToLower(name)]
+	return r, ok
+}
+
+// queueReviews upserts review items for the auction. Items already in the
+// queue keep their status; only the list of matches they were seen in grows.
+func queueReviews(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, cricbuzzMatchID int, reviews []models.MatchReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ops := make([]mongo.WriteModel, 0, len(reviews))
+	for _, r := range reviews {
+		filter := bson.M{"auction_id": auctionID, "cricbuzz_id": r.CricbuzzID}
+		if r.CricbuzzID == 0 {
+			filter["cricbuzz_name"] = r.CricbuzzName
+		}
+
+		insert := bson.M{
+			"kind":          r.Kind,
+			"cricbuzz_name": r.CricbuzzName,
+			"cricbuzz_team": r.CricbuzzTeam,
+			"confidence":    r.Confidence,
+			"status":        models.ReviewPending,
+			"created_at":    now,
+		}
+		if !r.SuggestedPlayerID.IsZero() {
+			insert["suggested_player_id"] = r.SuggestedPlayerID
+			insert["suggested_name"] = r.SuggestedName
+		}
+		if r.CricbuzzID == 0 {
+			delete(insert, "cricbuzz_name")
+		}
+
+		ops = append(ops, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{
+				"$setOnInsert": insert,
+				"$set":         bson.M{"updated_at": now},
+				"$addToSet":    bson.M{"cricbuzz_match_ids": cricbuzzMatchID},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := db.Collection(constants.ReviewCollection).BulkWrite(ctx, ops)
+	return err
+}
+
+// playerIndex returns the index of the player with the given ID, or -1.
+func playerIndex(players []models.Player, id primitive.ObjectID) int {
+	for i, p := range players {
+		if p.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordCalculatedPoints upserts the calculated side of the points ledger for
+// every matched player. Applied points are left alone until the match points
+// are saved again.
+func recordCalculatedPoints(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, providerName string, providerMatchID int, iplTeams []string, results []pointResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ops := make([]mongo.WriteModel, 0, len(results))
+	for _, r := range results {
+		matchID, err := primitive.ObjectIDFromHex(r.MatchID)
+		if err != nil {
+			continue
+		}
+		ops = append(ops, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"auction_id":        auctionID,
+				"provider":          providerName,
+				"provider_match_id": providerMatchID,
+				"match_id":          matchID,
+			}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"player_id":         r.PlayerID,
+					"player_name":       r.PlayerName,
+					"ipl_teams":         iplTeams,
+					"cricbuzz_id":       r.CricbuzzID,
+					"cricbuzz_name":     r.CricbuzzName,
+					"exact_name_match":  !r.MatchedByID && r.Confidence == 1.0 && r.CricbuzzID > 0,
+					"calculated_points": r.Points,
+					"breakdown":         r.Breakdown,
+					"calculated_at":     now,
+				},
+				"$setOnInsert": bson.M{"applied": false},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := db.Collection(constants.LedgerCollection).BulkWrite(ctx, ops)
+	return err
+}
+
+// bindExactMatches remembers the Cricbuzz ID of every applied player of a
+// real match who was found by an exact name, so the next calculation finds
+// them by ID. Players that already have a binding are left alone.
+func bindExactMatches(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, providerName string, providerMatchID int) error {
+	cursor, err := db.Collection(constants.LedgerCollection).Find(ctx, bson.M{
+		"auction_id":        auctionID,
+		"provider":          providerName,
+		"provider_match_id": providerMatchID,
+		"applied":           true,
+		"exact_name_match":  true,
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.PointsEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return err
+	}
+
+	ops := make([]mongo.WriteModel, 0, len(entries))
+	for _, e := range entries {
+		if e.PlayerID.IsZero() || e.CricbuzzID <= 0 {
+			continue
+		}
+		ops = append(ops, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": e.PlayerID, "cricbuzz_id": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"cricbuzz_id": e.CricbuzzID}}))
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	_, err = db.Collection(constants.PlayerCollection).BulkWrite(ctx, ops)
+	return err
+}
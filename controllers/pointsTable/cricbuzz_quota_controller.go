@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+
+	"cric-auction-monolith/services/provider"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// CricbuzzQuotaController reports the RapidAPI quota last seen in the Cricbuzz
+// rate-limit headers, along with request, retry and cache hit counters.
+func CricbuzzQuotaController(logger *zap.Logger, db *mongo.Database, scores provider.ScoreProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		quota, ok := provider.QuotaOf(scores)
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Score provider has no API quota"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Cricbuzz quota fetched successfully",
+			"provider": scores.Name(),
+			"quota":    quota,
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// DeleteFranchiseController removes a franchise from the registry.
+func DeleteFranchiseController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			FranchiseID primitive.ObjectID `json:"franchise_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		result, err := db.Collection(constants.FranchiseCollection).DeleteOne(ctx, bson.M{"_id": request.FranchiseID})
+		if err != nil {
+			logger.Error("failed to delete franchise", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete franchise"})
+			return
+		}
+		if result.DeletedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Franchise not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Franchise deleted successfully"})
+	}
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// DeleteGameweekController removes a gameweek that has not started.
+func DeleteGameweekController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			GameweekID primitive.ObjectID `json:"gameweek_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		result, err := db.Collection(constants.GameweekCollection).DeleteOne(ctx,
+			bson.M{
+				"_id":        request.GameweekID,
+				"rotated":    false,
+				"start_time": bson.M{"$gt": time.Now()},
+			},
+		)
+		if err != nil {
+			logger.Error("failed to delete gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete gameweek"})
+			return
+		}
+		if result.DeletedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Gameweek not found or already started"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Gameweek deleted successfully"})
+	}
+}
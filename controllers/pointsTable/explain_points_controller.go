@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/fantasy"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// countedPending is reported as counted_as for points calculated but not
+// applied yet.
+const countedPending = "pending"
+
+// ExplainPointsController explains a player's points for one real match
+// from the points ledger: the stored breakdown with every scoring line,
+// the duck penalty waived for bowlers, and whether the points counted as
+// earned or benched for the team that owns the player. provider defaults
+// to Cricbuzz.
+func ExplainPointsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID       primitive.ObjectID `json:"auction_id" binding:"required"`
+			PlayerID        primitive.ObjectID `json:"player_id" binding:"required"`
+			Provider        string             `json:"provider"`
+			ProviderMatchID int                `json:"provider_match_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+		if request.Provider == "" {
+			request.Provider = cricbuzz.ProviderName
+		}
+
+		var player models.Player
+		err := db.Collection(constants.PlayerCollection).FindOne(ctx,
+			bson.M{"_id": request.PlayerID, "auction_id": request.AuctionID},
+		).Decode(&player)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch player", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		var entry models.PointsEntry
+		err = db.Collection(constants.LedgerCollection).FindOne(ctx, bson.M{
+			"auction_id":        request.AuctionID,
+			"provider":          request.Provider,
+			"provider_match_id": request.ProviderMatchID,
+			"player_id":         request.PlayerID,
+		}).Decode(&entry)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No points recorded for this player in this match"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch points entry", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		// Entries stored before the waiver was recorded in the breakdown
+		// already have it in their points; only the breakdown needs it.
+		breakdown := fantasy.PlayerPoints{Breakdown: entry.Breakdown}.ForRole(player.Role).Breakdown
+
+		var team models.Team
+		err = db.Collection(constants.TeamCollection).FindOne(ctx,
+			bson.M{"auction_id": request.AuctionID, "squad": request.PlayerID},
+		).Decode(&team)
+		if err != nil && err != mongo.ErrNoDocuments {
+			logger.Error("failed to fetch owning team", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		countedAs := entry.CountedAs
+		if !entry.Applied {
+			countedAs = countedPending
+		}
+
+		matchDesc := ""
+		stored, err := loadStoredScorecard(ctx, db, request.AuctionID, request.Provider, request.ProviderMatchID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			logger.Warn("failed to fetch stored scorecard", zap.Error(err))
+		}
+		if err == nil && stored.Scorecard.MatchHeader != nil {
+			matchDesc = stored.Scorecard.MatchHeader.MatchDesc
+		}
+
+		response := gin.H{
+			"message":           "Points explained successfully",
+			"player_id":         player.Id,
+			"player_name":       player.PlayerName,
+			"role":              player.Role,
+			"provider":          entry.Provider,
+			"provider_match_id": entry.ProviderMatchID,
+			"match_desc":        matchDesc,
+			"cricbuzz_name":     entry.CricbuzzName,
+			"calculated_points": entry.CalculatedPoints,
+			"breakdown":         breakdown,
+			"calculated_at":     entry.CalculatedAt,
+			"applied":           entry.Applied,
+			"applied_points":    entry.Points,
+			"counted_as":        countedAs,
+			"gameweek":          entry.Gameweek,
+		}
+		if !team.ID.IsZero() {
+			response["team_id"] = team.ID
+			response["team_name"] = team.TeamName
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/stats"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// GetAwardsController returns the season awards of an auction's players:
+// orange and purple caps, MVP, best strike rate over min_balls faced, most
+// catches and the best buy on fantasy points per crore. limit sets the
+// leaders listed per award.
+func GetAwardsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			MinBalls  *int               `json:"min_balls" binding:"omitempty,min=0"`
+			Limit     *int               `json:"limit" binding:"omitempty,min=0"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		opts := stats.DefaultOptions()
+		if request.MinBalls != nil {
+			opts.MinBalls = *request.MinBalls
+		}
+		if request.Limit != nil {
+			opts.Limit = *request.Limit
+		}
+
+		rules, err := loadScoringRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch scoring rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		season, err := stats.Season(ctx, db, request.AuctionID, rules)
+		if err != nil {
+			logger.Error("failed to aggregate season stats", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Awards fetched successfully",
+			"options": opts,
+			"awards":  stats.Awards(season, opts),
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/franchise"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// GetFranchisesController lists the franchise registry of a series. Series
+// 0 holds the IPL franchises used by auctions without a series.
+func GetFranchisesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			SeriesID int `json:"series_id"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		franchises, err := franchise.List(ctx, db, request.SeriesID)
+		if err != nil {
+			logger.Error("failed to fetch franchises", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Franchises fetched successfully",
+			"franchises": franchises,
+		})
+	}
+}
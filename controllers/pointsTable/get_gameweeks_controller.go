@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/gameweek"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetGameweeksController lists the gameweeks of an auction in order, with the
+// auction's current gameweek and whether XI edits are locked right now.
+func GetGameweeksController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		now := time.Now()
+		if _, err := gameweek.RotateAuction(ctx, db, request.AuctionID, now); err != nil {
+			logger.Error("failed to rotate due gameweeks", zap.Error(err))
+		}
+
+		cursor, err := db.Collection(constants.GameweekCollection).Find(ctx,
+			bson.M{"auction_id": request.AuctionID},
+			options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}}),
+		)
+		if err != nil {
+			logger.Error("failed to fetch gameweeks", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		var gameweeks []models.Gameweek
+		if err := cursor.All(ctx, &gameweeks); err != nil {
+			logger.Error("failed to decode gameweeks", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		if gameweeks == nil {
+			gameweeks = []models.Gameweek{}
+		}
+
+		locked, next, err := gameweek.Locked(ctx, db, request.AuctionID, now)
+		if err != nil {
+			logger.Error("failed to check gameweek deadline", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		var auction models.Auction
+		err = db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+		if err != nil && err != mongo.ErrNoDocuments {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"gameweeks":        gameweeks,
+			"current_gameweek": auction.CurrentGameweek,
+			"next":             next,
+			"locked":           locked,
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/manual"
+	"cric-auction-monolith/services/scoring"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// GetLiveMatchController returns a live match with its cards as of the
+// last event.
+func GetLiveMatchController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			LiveMatchID primitive.ObjectID `json:"live_match_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		lm, match, err := loadLiveMatch(ctx, db, request.LiveMatchID)
+		if err != nil {
+			liveMatchError(c, logger, err)
+			return
+		}
+		state, err := scoring.Replay(match, lm.Events)
+		if err != nil {
+			logger.Error("failed to replay live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay match"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Live match fetched successfully",
+			"live_match": lm,
+			"state":      state,
+		})
+	}
+}
+
+// loadLiveMatch fetches a live match and the squads it is played with.
+func loadLiveMatch(ctx context.Context, db *mongo.Database, id primitive.ObjectID) (*models.LiveMatch, scoring.Match, error) {
+	var lm models.LiveMatch
+	if err := db.Collection(constants.LiveMatchCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&lm); err != nil {
+		return nil, scoring.Match{}, err
+	}
+	match, err := scoring.MatchOf(ctx, db, &lm)
+	return &lm, match, err
+}
+
+// liveMatchError writes the response for a loadLiveMatch error.
+func liveMatchError(c *gin.Context, logger *zap.Logger, err error) {
+	switch {
+	case err == mongo.ErrNoDocuments:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Live match not found"})
+	case errors.Is(err, scoring.ErrTeamNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+	default:
+		logger.Error("failed to fetch live match", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+	}
+}
+
+var errLiveMatchChanged = errors.New("live match changed")
+
+// saveLiveMatch stores the events of a live match and, once the match is
+// over, its scorecard as a manual match scored for the auction. It fails
+// with errLiveMatchChanged when another scorer saved first.
+func saveLiveMatch(ctx context.Context, logger *zap.Logger, db *mongo.Database, lm *models.LiveMatch, state *scoring.State) (*matchScore, error) {
+	now := time.Now()
+	lm.Status, lm.CompletedAt = models.LiveMatchInProgress, time.Time{}
+	var scorecard *cricbuzz.ScorecardResponse
+	if state.Complete {
+		// A match reopened by an undo keeps its manual match ID.
+		if lm.ProviderMatchID == 0 {
+			id, err := manual.NextMatchID(ctx, db)
+			if err != nil {
+				return nil, err
+			}
+			lm.ProviderMatchID = id
+		}
+		sc, err := manual.Build(lm.ProviderMatchID, state.Scorecard())
+		if err != nil {
+			return nil, err
+		}
+		scorecard = sc
+		lm.Status = models.LiveMatchComplete
+		lm.CompletedAt = now
+	}
+
+	res, err := db.Collection(constants.LiveMatchCollection).UpdateOne(ctx,
+		bson.M{"_id": lm.ID, "updated_at": lm.UpdatedAt},
+		bson.M{"$set": bson.M{
+			"events":            lm.Events,
+			"status":            lm.Status,
+			"provider_match_id": lm.ProviderMatchID,
+			"completed_at":      lm.CompletedAt,
+			"updated_at":        now,
+		}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, errLiveMatchChanged
+	}
+	lm.UpdatedAt = now
+
+	if scorecard == nil {
+		return nil, nil
+	}
+	if err := storeScorecard(ctx, db, lm.AuctionID, manual.ProviderName, lm.ProviderMatchID, scorecard); err != nil {
+		return nil, err
+	}
+	return scoreMatch(ctx, logger, db, lm.AuctionID, manual.ProviderName, lm.ProviderMatchID, nil, scorecard, true)
+}
@@ -19,12 +19,16 @@ type PlayerWithMatchData struct {
 	MatchData *models.Match `bson:"-" json:"match_data,omitempty"`
 }
 
+// GetMatchPlayersController lists the auction players of the two IPL teams
+// of a match, picked as a season fixture or typed as ipl_team1 and
+// ipl_team2.
 func GetMatchPlayersController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var request struct {
 			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
-			IPLTeam1  string             `json:"ipl_team1" binding:"required"`
-			IPLTeam2  string             `json:"ipl_team2" binding:"required"`
+			FixtureID primitive.ObjectID `json:"fixture_id"`
+			IPLTeam1  string             `json:"ipl_team1"`
+			IPLTeam2  string             `json:"ipl_team2"`
 		}
 
 		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
@@ -36,10 +40,16 @@ func GetMatchPlayersController(logger *zap.Logger, db *mongo.Database) gin.Handl
 			return
 		}
 
+		teams, fixture, err := fixtureTeams(ctx, db, request.AuctionID, request.FixtureID, request.IPLTeam1, request.IPLTeam2)
+		if err != nil {
+			fixtureError(c, logger, err)
+			return
+		}
+
 		// 1. Fetch players from both IPL teams in one query
 		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{
 			"auction_id": request.AuctionID,
-			"ipl_team":   bson.M{"$in": []string{request.IPLTeam1, request.IPLTeam2}},
+			"ipl_team":   bson.M{"$in": teams},
 		})
 		if err != nil {
 			logger.Error("failed to fetch players", zap.Error(err))
@@ -113,6 +123,7 @@ func GetMatchPlayersController(logger *zap.Logger, db *mongo.Database) gin.Handl
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Players fetched successfully",
 			"players": result,
+			"fixture": fixture,
 		})
 	}
 }
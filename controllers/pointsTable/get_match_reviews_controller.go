@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetMatchReviewsController lists the player match review queue of an auction.
+// Only pending items are returned unless another status is requested.
+func GetMatchReviewsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Status    string             `json:"status"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		filter := bson.M{"auction_id": request.AuctionID}
+		switch request.Status {
+		case "":
+			filter["status"] = models.ReviewPending
+		case "all":
+		default:
+			filter["status"] = request.Status
+		}
+
+		cursor, err := db.Collection(constants.ReviewCollection).Find(ctx, filter,
+			options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}),
+		)
+		if err != nil {
+			logger.Error("failed to fetch match reviews", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		defer cursor.Close(ctx)
+
+		reviews := make([]models.MatchReview, 0)
+		if err = cursor.All(ctx, &reviews); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Decode error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Match reviews fetched successfully",
+			"reviews": reviews,
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/stats"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// GetPlayerStatsController returns the season statistics of an auction's
+// players, aggregated from the stored scorecards of their scored matches.
+// With player_id it returns that player with a line per match; otherwise
+// every player matching role, ipl_team and team_id, sorted by sort_by
+// (points by default) in order.
+func GetPlayerStatsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			PlayerID  primitive.ObjectID `json:"player_id"`
+			stats.Filter
+			SortBy string `json:"sort_by"`
+			Order  string `json:"order"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		rules, err := loadScoringRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch scoring rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		if !request.PlayerID.IsZero() {
+			player, matches, err := stats.PlayerSeason(ctx, db, request.AuctionID, request.PlayerID, rules)
+			if err == stats.ErrNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to aggregate player stats", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Player stats fetched successfully",
+				"player":  player,
+				"matches": matches,
+			})
+			return
+		}
+
+		season, err := stats.Season(ctx, db, request.AuctionID, rules)
+		if err != nil {
+			logger.Error("failed to aggregate season stats", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		players := request.Filter.Apply(season)
+		if err := stats.Sort(players, request.SortBy, request.Order); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Player stats fetched successfully",
+			"players": players,
+		})
+	}
+}
@@ -3,7 +3,9 @@ package controllers
 import (
 	"context"
 	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/gameweek"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,6 +28,10 @@ func GetScoreboardController(logger *zap.Logger, db *mongo.Database) gin.Handler
 			return
 		}
 
+		if _, err := gameweek.RotateAuction(ctx, db, request.AuctionID, time.Now()); err != nil {
+			logger.Error("failed to rotate due gameweeks", zap.Error(err))
+		}
+
 		// Single aggregation pipeline: players → lookup matches → sort → project
 		pipeline := mongo.Pipeline{
 			// Match IPL players in this auction
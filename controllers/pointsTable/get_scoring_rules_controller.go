@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/fantasy"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetScoringRulesController returns the fantasy scoring rules of an auction,
+// falling back to the defaults for anything not configured.
+func GetScoringRulesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		rules, err := loadScoringRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch scoring rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Scoring rules fetched successfully",
+			"rules":   rules,
+		})
+	}
+}
+
+// loadScoringRules reads the scoring_rules of an auction on top of the
+// default rules, so fields the auction never set keep their default value.
+func loadScoringRules(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (fantasy.Rules, error) {
+	doc := struct {
+		ScoringRules fantasy.Rules `bson:"scoring_rules"`
+	}{ScoringRules: fantasy.DefaultRules()}
+
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx,
+		bson.M{"_id": auctionID},
+		options.FindOne().SetProjection(bson.M{"scoring_rules": 1}),
+	).Decode(&doc)
+	return doc.ScoringRules, err
+}
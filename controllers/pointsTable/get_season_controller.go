@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/season"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// GetSeasonController returns the imported season of an auction with its
+// fixtures in start order.
+func GetSeasonController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		s, fixtures, err := season.Load(ctx, db, request.AuctionID)
+		if errors.Is(err, season.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No season imported for this auction"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch season", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Season fetched successfully",
+			"season":   s,
+			"fixtures": fixtures,
+		})
+	}
+}
+
+var errNoTeams = errors.New("fixture_id or ipl_team1 and ipl_team2 are required")
+
+// fixtureTeams returns the IPL teams of a match-day request: those of the
+// picked season fixture, or the two typed team names.
+func fixtureTeams(ctx context.Context, db *mongo.Database, auctionID, fixtureID primitive.ObjectID, team1, team2 string) ([]string, *models.SeasonFixture, error) {
+	if fixtureID.IsZero() {
+		if team1 == "" || team2 == "" {
+			return nil, nil, errNoTeams
+		}
+		return []string{team1, team2}, nil, nil
+	}
+	f, err := season.Fixture(ctx, db, auctionID, fixtureID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f.Teams(), f, nil
+}
+
+// fixtureError writes the response for a fixtureTeams error.
+func fixtureError(c *gin.Context, logger *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, errNoTeams):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, season.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fixture not found"})
+	default:
+		logger.Error("failed to fetch fixture", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+	}
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/franchise"
+	"cric-auction-monolith/services/provider"
+	"cric-auction-monolith/services/season"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ImportSeasonController imports the fixture schedule of an auction's
+// season, replacing any earlier import. With source "provider" the schedule
+// of series_id, or of the auction's series, is fetched from the score
+// provider; with source "csv" it is read from the csv field.
+func ImportSeasonController(logger *zap.Logger, db *mongo.Database, scores provider.ScoreProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Name      string             `json:"name"`
+			Source    string             `json:"source" binding:"required"`
+			SeriesID  int                `json:"series_id"`
+			CSV       string             `json:"csv"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		var auction models.Auction
+		err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		s := models.Season{
+			AuctionID: request.AuctionID,
+			Name:      request.Name,
+			Source:    request.Source,
+		}
+		var fixtures []models.SeasonFixture
+
+		switch request.Source {
+		case models.SeasonSourceProvider:
+			s.SeriesID = request.SeriesID
+			if s.SeriesID == 0 {
+				s.SeriesID = auction.SeriesID
+			}
+			if s.SeriesID <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "series_id is required when the auction has no series"})
+				return
+			}
+			resp, err := scores.FetchSeriesMatches(ctx, s.SeriesID)
+			if errors.Is(err, cricbuzz.ErrQuotaExhausted) {
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": "Cricbuzz API quota exhausted"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to fetch series matches", zap.Error(err))
+				c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch the series schedule"})
+				return
+			}
+			s.Provider = scores.Name()
+			if s.Name == "" {
+				s.Name = auction.SeriesName
+			}
+			fixtures = season.FromProvider(scores.Name(), resp)
+		case models.SeasonSourceCSV:
+			if strings.TrimSpace(request.CSV) == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "csv is required"})
+				return
+			}
+			fixtures, err = season.ParseCSV(strings.NewReader(request.CSV), scores.Name())
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			s.Provider = scores.Name()
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "source must be provider or csv"})
+			return
+		}
+		if s.Name == "" {
+			s.Name = auction.AuctionName
+		}
+		if len(fixtures) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "No fixtures to import"})
+			return
+		}
+
+		teams, err := franchise.Aliases(ctx, db, auction.SeriesID)
+		if err != nil {
+			logger.Error("failed to fetch franchises", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		saved, fixtures, err := season.Import(ctx, db, s, fixtures, teams)
+		if err != nil {
+			logger.Error("failed to import season", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import season"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Season imported successfully",
+			"season":   saved,
+			"fixtures": fixtures,
+		})
+	}
+}
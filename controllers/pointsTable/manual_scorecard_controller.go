@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/manual"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ManualScorecardController stores a scorecard entered by hand, for club
+// matches no score provider covers, and scores it like a fetched one
+// against all players of the auction. Without a match_id a new manual match
+// is created; with one, that match's scorecard is replaced and rescored if it
+// belongs to the auction.
+func ManualScorecardController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			MatchID   int                `json:"match_id"`
+			manual.Scorecard
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		count, err := db.Collection(constants.AuctionCollection).CountDocuments(ctx, bson.M{"_id": request.AuctionID})
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		if count == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+
+		var invalid *manual.Error
+		if err := manual.Resolve(ctx, db, request.AuctionID, &request.Scorecard); err != nil {
+			if errors.As(err, &invalid) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			logger.Error("failed to fetch players", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		matchID := request.MatchID
+		if matchID == 0 {
+			matchID, err = manual.NextMatchID(ctx, db)
+			if err != nil {
+				logger.Error("failed to number manual match", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+		} else {
+			_, err := loadStoredScorecard(ctx, db, request.AuctionID, manual.ProviderName, matchID)
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Manual match not found"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to fetch stored scorecard", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+		}
+
+		scorecard, err := manual.Build(matchID, request.Scorecard)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := storeScorecard(ctx, db, request.AuctionID, manual.ProviderName, matchID, scorecard); err != nil {
+			logger.Error("failed to store scorecard", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store scorecard"})
+			return
+		}
+
+		result, err := scoreMatch(ctx, logger, db, request.AuctionID, manual.ProviderName, matchID, nil, scorecard, true)
+		if err != nil {
+			logger.Error("failed to score match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":            "Scorecard saved successfully",
+			"provider":           manual.ProviderName,
+			"match_id":           matchID,
+			"scorecard":          scorecard,
+			"points":             result.Points,
+			"unmatched_cricbuzz": result.UnmatchedCricbuzz,
+			"unmatched_db":       result.UnmatchedDB,
+			"ignored_cricbuzz":   result.IgnoredCricbuzz,
+			"reviews_queued":     result.ReviewsQueued,
+			"match":              result.Match,
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"cric-auction-monolith/services/fantasy"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ParseDismissalsController shows how dismissal texts are read for scoring,
+// e.g. to check a scorecard that scored unexpectedly.
+func ParseDismissalsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			Texts []string `json:"texts"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		dismissals := make([]fantasy.DismissalInfo, 0, len(request.Texts))
+		for _, text := range request.Texts {
+			dismissals = append(dismissals, fantasy.ParseDismissal(text))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Dismissals parsed successfully",
+			"dismissals": dismissals,
+		})
+	}
+}
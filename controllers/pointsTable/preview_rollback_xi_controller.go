@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/gameweek"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// PreviewRollbackXIController shows what rolling back to the end of a
+// gameweek would change, per player, without changing anything.
+func PreviewRollbackXIController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Gameweek  *int               `json:"gameweek"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		target, err := rollbackTarget(ctx, db, request.AuctionID, request.Gameweek)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		rb, err := gameweek.PreviewRollback(ctx, db, request.AuctionID, target)
+		if err == gameweek.ErrNoSnapshot {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No snapshot for that gameweek"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to preview rollback", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview rollback"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rollback": rb})
+	}
+}
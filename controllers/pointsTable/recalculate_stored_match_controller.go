@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/manual"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RecalculateStoredMatchController recalculates a match from its stored
+// scorecard with the current scoring rules, without calling the provider.
+// The match is picked as a season fixture or given by provider match ID and
+// IPL teams.
+func RecalculateStoredMatchController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID       primitive.ObjectID `json:"auction_id" binding:"required"`
+			Provider        string             `json:"provider"`
+			ProviderMatchID int                `json:"provider_match_id"`
+			FixtureID       primitive.ObjectID `json:"fixture_id"`
+			IPLTeam1        string             `json:"ipl_team1"`
+			IPLTeam2        string             `json:"ipl_team2"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+		teams, fixture, err := fixtureTeams(ctx, db, request.AuctionID, request.FixtureID, request.IPLTeam1, request.IPLTeam2)
+		if errors.Is(err, errNoTeams) && request.Provider == manual.ProviderName {
+			// Manual scorecards are club matches, scored against all players.
+			err = nil
+		}
+		if err != nil {
+			fixtureError(c, logger, err)
+			return
+		}
+		if request.ProviderMatchID == 0 && fixture != nil {
+			request.Provider = fixture.Provider
+			request.ProviderMatchID = fixture.ProviderMatchID
+		}
+		if request.ProviderMatchID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "provider_match_id is required"})
+			return
+		}
+		if request.Provider == "" {
+			request.Provider = cricbuzz.ProviderName
+		}
+
+		stored, err := loadStoredScorecard(ctx, db, request.AuctionID, request.Provider, request.ProviderMatchID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scorecard not stored for this match"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch stored scorecard", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		result, err := scoreMatch(ctx, logger, db, request.AuctionID, request.Provider, request.ProviderMatchID,
+			teams, &stored.Scorecard, true)
+		if err != nil {
+			logger.Error("failed to score match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":            "Points recalculated successfully",
+			"fetched_at":         stored.FetchedAt,
+			"hash":               stored.Hash,
+			"points":             result.Points,
+			"unmatched_cricbuzz": result.UnmatchedCricbuzz,
+			"unmatched_db":       result.UnmatchedDB,
+			"ignored_cricbuzz":   result.IgnoredCricbuzz,
+			"reviews_queued":     result.ReviewsQueued,
+			"match":              result.Match,
+		})
+	}
+}
+
+// storeScorecard saves the latest scorecard of a provider match together with
+// the time it was fetched and a SHA-256 hash of its JSON. A scorecard without
+// innings is never stored, an unchanged one is not rewritten, and a feed
+// scorecard still in progress never replaces a complete one. The manual
+// provider may go back to in progress when the scorer undoes the last ball.
+// Manual scorecards are stored with the auction they were entered for.
+func storeScorecard(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, provider string, providerMatchID int, scorecard *cricbuzz.ScorecardResponse) error {
+	if scorecard == nil || len(scorecard.Scorecard) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(scorecard)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+
+	stored, err := loadStoredScorecard(ctx, db, auctionID, provider, providerMatchID)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+	case err != nil:
+		return err
+	case stored.Hash == hash:
+		return nil
+	case provider != manual.ProviderName && stored.Scorecard.IsMatchComplete && !scorecard.IsMatchComplete:
+		return nil
+	}
+
+	now := time.Now()
+	_, err = db.Collection(constants.ScorecardCollection).UpdateOne(ctx,
+		scorecardFilter(auctionID, provider, providerMatchID),
+		bson.M{
+			"$set": bson.M{
+				"hash":       hash,
+				"fetched_at": now,
+				"scorecard":  scorecard,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func loadStoredScorecard(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, provider string, providerMatchID int) (models.Scorecard, error) {
+	var stored models.Scorecard
+	err := db.Collection(constants.ScorecardCollection).FindOne(ctx,
+		scorecardFilter(auctionID, provider, providerMatchID),
+	).Decode(&stored)
+	return stored, err
+}
+
+// scorecardFilter finds the stored scorecard of a provider match. Provider
+// scorecards are shared by all auctions; a manual one is only found by the
+// auction that owns it.
+func scorecardFilter(auctionID primitive.ObjectID, provider string, providerMatchID int) bson.M {
+	filter := bson.M{"provider": provider, "provider_match_id": providerMatchID}
+	if provider == manual.ProviderName {
+		filter["auction_id"] = auctionID
+	}
+	return filter
+}
@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/manual"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RecomputePointsController replays every applied match of an auction
+// through the current calculator using the stored scorecards. Points are
+// compared with what was applied, keeping the XI each entry counted for, and
+// the differences are saved as a pending recompute job. Nothing is changed
+// until the job is applied with ApplyRecomputeController.
+//
+// Entries whose player the replay no longer matches are listed as unmatched
+// and keep their points; applying the job does not touch them.
+func RecomputePointsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		// Replaying a season touches many scorecards.
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 6*constants.DBTimeout)
+		defer cancel()
+
+		cursor, err := db.Collection(constants.LedgerCollection).Find(ctx, bson.M{
+			"auction_id": request.AuctionID,
+			"applied":    true,
+		})
+		if err != nil {
+			logger.Error("failed to fetch points ledger", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		var entries []models.PointsEntry
+		if err := cursor.All(ctx, &entries); err != nil {
+			logger.Error("failed to decode points ledger", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		if len(entries) == 0 {
+			c.JSON(http.StatusOK, gin.H{"message": "No applied points to recompute"})
+			return
+		}
+
+		// Group the ledger by real match.
+		type matchKey struct {
+			provider string
+			id       int
+		}
+		groups := make(map[matchKey][]models.PointsEntry)
+		var order []matchKey
+		for _, e := range entries {
+			k := matchKey{e.Provider, e.ProviderMatchID}
+			if _, ok := groups[k]; !ok {
+				order = append(order, k)
+			}
+			groups[k] = append(groups[k], e)
+		}
+
+		teamOf, err := playerTeams(ctx, db, request.AuctionID)
+		if err != nil {
+			logger.Error("failed to fetch teams", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		job := models.RecomputeJob{
+			AuctionID: request.AuctionID,
+			Status:    models.RecomputePending,
+			Skipped:   []string{},
+			Entries:   []models.RecomputeEntry{},
+			Unmatched: []models.RecomputeEntry{},
+			Players:   []models.RecomputePlayer{},
+			Teams:     []models.RecomputeTeam{},
+			CreatedBy: c.GetString("email"),
+			CreatedAt: time.Now(),
+		}
+		players := make(map[primitive.ObjectID]*models.RecomputePlayer)
+		teams := make(map[primitive.ObjectID]*models.RecomputeTeam)
+
+		for _, k := range order {
+			group := groups[k]
+			label := fmt.Sprintf("%s:%d", k.provider, k.id)
+
+			// Manual scorecards are club matches, scored against all players.
+			if len(group[0].IPLTeams) == 0 && k.provider != manual.ProviderName {
+				job.Skipped = append(job.Skipped, label+" (no IPL teams recorded)")
+				continue
+			}
+			stored, err := loadStoredScorecard(ctx, db, request.AuctionID, k.provider, k.id)
+			if err == mongo.ErrNoDocuments {
+				job.Skipped = append(job.Skipped, label+" (scorecard not stored)")
+				continue
+			}
+			if err != nil {
+				logger.Error("failed to load stored scorecard", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+
+			score, err := scoreMatch(ctx, logger, db, request.AuctionID, k.provider, k.id, group[0].IPLTeams, &stored.Scorecard, false)
+			if err != nil {
+				logger.Error("failed to score stored match", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recompute points"})
+				return
+			}
+			job.Matches++
+
+			newPoints := make(map[string]int, len(score.Points))
+			for _, p := range score.Points {
+				newPoints[p.MatchID] = p.Points
+			}
+
+			for _, e := range group {
+				np, matched := newPoints[e.MatchID.Hex()]
+				if !matched {
+					// Not a zero score: the replay no longer finds this
+					// player, so the change needs a review first.
+					np = e.Points
+					job.Unmatched = append(job.Unmatched, models.RecomputeEntry{
+						EntryID:         e.ID,
+						ProviderMatchID: e.ProviderMatchID,
+						MatchID:         e.MatchID,
+						PlayerName:      e.PlayerName,
+						Gameweek:        e.Gameweek,
+						MatchIndex:      e.MatchIndex,
+						CountedAs:       e.CountedAs,
+						OldPoints:       e.Points,
+						NewPoints:       e.Points,
+					})
+				}
+
+				if team, ok := teamOf[e.PlayerID]; ok {
+					t := teams[team.ID]
+					if t == nil {
+						t = &models.RecomputeTeam{TeamID: team.ID, TeamName: team.TeamName}
+						teams[team.ID] = t
+					}
+					if e.CountedAs == models.CountedEarned {
+						t.OldEarned += e.Points
+						t.NewEarned += np
+					} else {
+						t.OldBenched += e.Points
+						t.NewBenched += np
+					}
+				}
+
+				if np == e.Points {
+					continue
+				}
+				job.Entries = append(job.Entries, models.RecomputeEntry{
+					EntryID:         e.ID,
+					ProviderMatchID: e.ProviderMatchID,
+					MatchID:         e.MatchID,
+					PlayerName:      e.PlayerName,
+					Gameweek:        e.Gameweek,
+					MatchIndex:      e.MatchIndex,
+					CountedAs:       e.CountedAs,
+					OldPoints:       e.Points,
+					NewPoints:       np,
+				})
+
+				p := players[e.MatchID]
+				if p == nil {
+					p = &models.RecomputePlayer{MatchID: e.MatchID, PlayerName: e.PlayerName}
+					players[e.MatchID] = p
+				}
+				p.OldPoints += e.Points
+				p.NewPoints += np
+			}
+		}
+
+		for _, p := range players {
+			job.Players = append(job.Players, *p)
+		}
+		sort.Slice(job.Players, func(i, j int) bool {
+			return job.Players[i].PlayerName < job.Players[j].PlayerName
+		})
+		for _, t := range teams {
+			if t.OldEarned != t.NewEarned || t.OldBenched != t.NewBenched {
+				job.Teams = append(job.Teams, *t)
+			}
+		}
+		sort.Slice(job.Teams, func(i, j int) bool {
+			return job.Teams[i].TeamName < job.Teams[j].TeamName
+		})
+
+		res, err := db.Collection(constants.RecomputeCollection).InsertOne(ctx, job)
+		if err != nil {
+			logger.Error("failed to save recompute job", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save recompute job"})
+			return
+		}
+		job.ID = res.InsertedID.(primitive.ObjectID)
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Points recomputed successfully",
+			"job":     job,
+		})
+	}
+}
+
+// playerTeams maps every squad player of an auction to their team.
+func playerTeams(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (map[primitive.ObjectID]models.Team, error) {
+	cursor, err := db.Collection(constants.TeamCollection).Find(ctx, bson.M{"auction_id": auctionID})
+	if err != nil {
+		return nil, err
+	}
+	var teams []models.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, err
+	}
+
+	teamOf := make(map[primitive.ObjectID]models.Team)
+	for _, t := range teams {
+		for _, id := range t.Squad {
+			teamOf[id] = t
+		}
+	}
+	return teamOf, nil
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/scoring"
+	"cric-auction-monolith/services/tournament"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RecordClubResultController records the result of a club fixture, either
+// from a completed live match between its teams or from both innings as
+// entered. The winner is the side with more runs; winner_id settles a tie
+// decided another way, such as a super over. A no_result needs no innings.
+// Recording again replaces the result.
+func RecordClubResultController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID   primitive.ObjectID  `json:"auction_id" binding:"required"`
+			FixtureID   primitive.ObjectID  `json:"fixture_id" binding:"required"`
+			LiveMatchID primitive.ObjectID  `json:"live_match_id"`
+			NoResult    bool                `json:"no_result"`
+			Team1       *models.ClubInnings `json:"team1"`
+			Team2       *models.ClubInnings `json:"team2"`
+			WinnerID    primitive.ObjectID  `json:"winner_id"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		fixture, err := tournament.Get(ctx, db, request.AuctionID, request.FixtureID)
+		if err == tournament.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Fixture not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch club fixture", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		var result models.ClubResult
+		switch {
+		case request.NoResult:
+			result.Outcome = models.OutcomeNoResult
+			if request.Team1 != nil {
+				result.Team1 = *request.Team1
+			}
+			if request.Team2 != nil {
+				result.Team2 = *request.Team2
+			}
+		case !request.LiveMatchID.IsZero():
+			lm, match, err := loadLiveMatch(ctx, db, request.LiveMatchID)
+			if err == nil && lm.AuctionID != request.AuctionID {
+				err = mongo.ErrNoDocuments
+			}
+			if err != nil {
+				liveMatchError(c, logger, err)
+				return
+			}
+			state, err := scoring.Replay(match, lm.Events)
+			if err != nil {
+				logger.Error("failed to replay live match", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay match"})
+				return
+			}
+			result, err = tournament.FromLive(fixture, lm, state)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		case request.Team1 != nil && request.Team2 != nil:
+			result.Team1, result.Team2 = *request.Team1, *request.Team2
+			result.Outcome = tournament.Decide(result.Team1, result.Team2)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Give team1 and team2, a live_match_id or no_result"})
+			return
+		}
+
+		for i, inn := range []models.ClubInnings{result.Team1, result.Team2} {
+			if err := validateClubInnings(inn, fixture.Overs); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("team%d: %v", i+1, err)})
+				return
+			}
+		}
+
+		if !request.WinnerID.IsZero() {
+			switch {
+			case result.Outcome != models.OutcomeTie:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "winner_id only settles a tied match"})
+				return
+			case request.WinnerID == fixture.Team1ID:
+				result.Outcome = models.OutcomeTeam1
+			case request.WinnerID == fixture.Team2ID:
+				result.Outcome = models.OutcomeTeam2
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "winner_id must be one of the fixture's teams"})
+				return
+			}
+		}
+
+		now := time.Now()
+		result.RecordedAt = now
+		_, err = db.Collection(constants.ClubFixtureCollection).UpdateOne(ctx,
+			bson.M{"_id": fixture.ID},
+			bson.M{"$set": bson.M{"result": result, "updated_at": now}},
+		)
+		if err != nil {
+			logger.Error("failed to record club result", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record result"})
+			return
+		}
+		fixture.Result = &result
+		fixture.UpdatedAt = now
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Result recorded successfully",
+			"fixture": fixture,
+		})
+	}
+}
+
+// validateClubInnings checks an innings against the fixture's overs.
+func validateClubInnings(inn models.ClubInnings, overs int) error {
+	if inn.Runs < 0 {
+		return errors.New("runs must be >= 0")
+	}
+	if inn.Wickets < 0 || inn.Wickets > 10 {
+		return errors.New("wickets must be between 0 and 10")
+	}
+	if inn.Overs == "" {
+		inn.Overs = "0"
+	}
+	balls, err := tournament.ParseOvers(inn.Overs)
+	if err != nil {
+		return err
+	}
+	if overs > 0 && balls > overs*6 {
+		return fmt.Errorf("overs must be at most %d", overs)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/scoring"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RecordLiveEventController records the next event of a live match: a
+// batter coming in, a bowler change, a delivery, a retirement or the end of
+// an innings. The event that ends the match stores the scorecard as a
+// manual match and scores it for the auction.
+func RecordLiveEventController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			LiveMatchID primitive.ObjectID `json:"live_match_id" binding:"required"`
+			models.LiveEvent
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		lm, match, err := loadLiveMatch(ctx, db, request.LiveMatchID)
+		if err != nil {
+			liveMatchError(c, logger, err)
+			return
+		}
+		state, err := scoring.Replay(match, lm.Events)
+		if err != nil {
+			logger.Error("failed to replay live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay match"})
+			return
+		}
+
+		event := request.LiveEvent
+		event.At = time.Now()
+		if err := state.Apply(event); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		lm.Events = append(lm.Events, event)
+
+		result, err := saveLiveMatch(ctx, logger, db, lm, state)
+		if errors.Is(err, errLiveMatchChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Match was updated by another scorer, reload it"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to save live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save match"})
+			return
+		}
+
+		response := gin.H{
+			"message":    "Event recorded successfully",
+			"live_match": lm,
+			"state":      state,
+		}
+		if result != nil {
+			response["points"] = result.Points
+			response["unmatched_db"] = result.UnmatchedDB
+			response["match"] = result.Match
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"cric-auction-monolith/core/constants"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,7 +15,9 @@ import (
 )
 
 // ResetPointsController sets earned, benched, total, prev earned, prev benched,
-// prev total points to 0 for all players in an auction. Also resets matches array.
+// prev total points to 0 for all players in an auction. Also resets matches array,
+// un-applies the points ledger, drops the XI and leaderboard history and starts
+// the gameweek count again, with every gameweek waiting to be rotated.
 func ResetPointsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var request struct {
@@ -81,6 +84,40 @@ func ResetPointsController(logger *zap.Logger, db *mongo.Database) gin.HandlerFu
 			return
 		}
 
+		_, err = db.Collection(constants.LedgerCollection).UpdateMany(ctx,
+			bson.M{"auction_id": request.AuctionID, "applied": true},
+			bson.M{
+				"$set":   bson.M{"applied": false},
+				"$unset": bson.M{"points": "", "match_index": "", "gameweek": "", "counted_as": "", "applied_at": ""},
+			},
+		)
+		if err != nil {
+			logger.Error("failed to clear points ledger", zap.Error(err))
+		}
+		if _, err := db.Collection(constants.XISnapshotCollection).DeleteMany(ctx, bson.M{"auction_id": request.AuctionID}); err != nil {
+			logger.Error("failed to clear XI history", zap.Error(err))
+		}
+		if _, err := db.Collection(constants.LeaderboardCollection).DeleteMany(ctx, bson.M{"auction_id": request.AuctionID}); err != nil {
+			logger.Error("failed to clear leaderboard history", zap.Error(err))
+		}
+		_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+			bson.M{"_id": request.AuctionID},
+			bson.M{"$set": bson.M{"current_gameweek": 0}},
+		)
+		if err != nil {
+			logger.Error("failed to reset gameweek", zap.Error(err))
+		}
+		_, err = db.Collection(constants.GameweekCollection).UpdateMany(ctx,
+			bson.M{"auction_id": request.AuctionID},
+			bson.M{
+				"$set":   bson.M{"rotated": false, "updated_at": time.Now()},
+				"$unset": bson.M{"rotated_at": ""},
+			},
+		)
+		if err != nil {
+			logger.Error("failed to reset gameweek rotations", zap.Error(err))
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "All points reset to 0",
 			"updated": result.ModifiedCount,
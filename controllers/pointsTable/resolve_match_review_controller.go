@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ResolveMatchReviewController resolves a review queue item:
+//   - accept: use the suggested player
+//   - reassign: use the player given in player_id
+//   - ignore: skip this Cricbuzz player in future calculations
+//
+// Accepted and reassigned players with a Cricbuzz ID are bound to that ID, so
+// future calculations match them directly.
+func ResolveMatchReviewController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			ReviewID primitive.ObjectID `json:"review_id" binding:"required"`
+			Action   string             `json:"action" binding:"required,oneof=accept reassign ignore"`
+			PlayerID primitive.ObjectID `json:"player_id"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		var review models.MatchReview
+		err := db.Collection(constants.ReviewCollection).FindOne(ctx, bson.M{"_id": request.ReviewID}).Decode(&review)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch match review", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		var (
+			status   string
+			playerID primitive.ObjectID
+		)
+		switch request.Action {
+		case "accept":
+			if review.SuggestedPlayerID.IsZero() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Review has no suggested player, use reassign"})
+				return
+			}
+			status, playerID = models.ReviewAccepted, review.SuggestedPlayerID
+		case "reassign":
+			if request.PlayerID.IsZero() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "player_id is required to reassign"})
+				return
+			}
+			status, playerID = models.ReviewReassigned, request.PlayerID
+		case "ignore":
+			status = models.ReviewIgnored
+		}
+
+		if !playerID.IsZero() && review.CricbuzzID > 0 {
+			_, err := bindCricbuzzID(ctx, db, review.AuctionID, playerID, review.CricbuzzID)
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Player not found in this auction"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to bind cricbuzz player", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save Cricbuzz binding"})
+				return
+			}
+		} else if !playerID.IsZero() {
+			// Nothing to bind, but the player must still be in this auction.
+			err := db.Collection(constants.PlayerCollection).FindOne(ctx, bson.M{
+				"_id":        playerID,
+				"auction_id": review.AuctionID,
+			}).Err()
+			if err == mongo.ErrNoDocuments {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Player not found in this auction"})
+				return
+			}
+			if err != nil {
+				logger.Error("failed to fetch player", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+		}
+
+		now := time.Now()
+		set := bson.M{
+			"status":      status,
+			"resolved_by": c.GetString("email"),
+			"resolved_at": now,
+			"updated_at":  now,
+		}
+		update := bson.M{"$set": set}
+		if playerID.IsZero() {
+			update["$unset"] = bson.M{"resolved_player_id": ""}
+		} else {
+			set["resolved_player_id"] = playerID
+		}
+
+		err = db.Collection(constants.ReviewCollection).FindOneAndUpdate(ctx,
+			bson.M{"_id": review.ID},
+			update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&review)
+		if err != nil {
+			logger.Error("failed to resolve match review", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve review"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Review resolved successfully",
+			"review":  review,
+		})
+	}
+}
@@ -3,6 +3,8 @@ package controllers
 import (
 	"context"
 	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/gameweek"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,16 +15,24 @@ import (
 	"go.uber.org/zap"
 )
 
-// RollbackXIController reverses the Change XI operation:
+// RollbackXIController rolls the auction back to the end of an earlier
+// gameweek from its snapshot. Without a gameweek it undoes the last Change XI;
+// rolling back further needs confirm, after checking the preview.
+//
+// Auctions rotated before snapshots existed fall back to reversing the last
+// Change XI operation in place:
 //   - currentX1 → nextX1
 //   - prevX1 → currentX1
 //   - prevX1 stays as-is
 //   - Restores prev earned/benched/total points
 //   - Resets matches array to zeros
+//   - Un-applies the ledger entries of the discarded gameweek
 func RollbackXIController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var request struct {
 			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Gameweek  *int               `json:"gameweek"`
+			Confirm   bool               `json:"confirm"`
 		}
 
 		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
@@ -33,6 +43,41 @@ func RollbackXIController(logger *zap.Logger, db *mongo.Database) gin.HandlerFun
 			return
 		}
 
+		target, err := rollbackTarget(ctx, db, request.AuctionID, request.Gameweek)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		if request.Gameweek != nil && !request.Confirm {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "confirm must be true to roll back to a gameweek"})
+			return
+		}
+
+		rb, err := gameweek.RollbackTo(ctx, db, request.AuctionID, target)
+		if err == nil {
+			c.JSON(http.StatusOK, gin.H{
+				"message":  "XI rollback successful",
+				"updated":  len(rb.Players),
+				"rollback": rb,
+			})
+			return
+		}
+		if err != gameweek.ErrNoSnapshot {
+			logger.Error("failed to rollback XI", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rollback XI"})
+			return
+		}
+		if request.Gameweek != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No snapshot for that gameweek"})
+			return
+		}
+
 		// Fetch all match IDs for this auction's players.
 		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx,
 			bson.M{
@@ -88,9 +133,61 @@ func RollbackXIController(logger *zap.Logger, db *mongo.Database) gin.HandlerFun
 			return
 		}
 
+		if err := rollbackGameweek(ctx, db, request.AuctionID); err != nil {
+			logger.Error("failed to roll back gameweek", zap.Error(err))
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"message": "XI rollback successful",
 			"updated": result.ModifiedCount,
 		})
 	}
 }
+
+// rollbackGameweek marks the points applied in the current gameweek as no
+// longer applied (they were just discarded), drops the leaderboard of the
+// reopened gameweek and steps the gameweek back.
+func rollbackGameweek(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) error {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(constants.LedgerCollection).UpdateMany(ctx,
+		bson.M{"auction_id": auctionID, "applied": true, "gameweek": auction.CurrentGameweek},
+		bson.M{
+			"$set":   bson.M{"applied": false},
+			"$unset": bson.M{"points": "", "match_index": "", "gameweek": "", "counted_as": "", "applied_at": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if auction.CurrentGameweek == 0 {
+		return nil
+	}
+	_, err = db.Collection(constants.LeaderboardCollection).DeleteMany(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": bson.M{"$gte": auction.CurrentGameweek - 1}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+		bson.M{"_id": auctionID, "current_gameweek": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"current_gameweek": -1}},
+	)
+	return err
+}
+
+// rollbackTarget is the gameweek to roll back to: the requested one, or the
+// one before the current gameweek.
+func rollbackTarget(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, requested *int) (int, error) {
+	if requested != nil {
+		return *requested, nil
+	}
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	return auction.CurrentGameweek - 1, err
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/gameweek"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RotateDueGameweeksController starts every gameweek whose start time has
+// passed, across all auctions. It is what the background scheduler does and
+// is meant for an external cron where no scheduler runs, such as the
+// serverless deployment. Callers authenticate with the CRON_SECRET
+// environment variable as a bearer token; without it the endpoint is off.
+func RotateDueGameweeksController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := os.Getenv("CRON_SECRET")
+		if secret == "" {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "CRON_SECRET is not configured"})
+			return
+		}
+		got := c.GetHeader("Authorization")
+		if subtle.ConstantTimeCompare([]byte(got), []byte("Bearer "+secret)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		rotated, err := gameweek.RotateDue(ctx, db, time.Now())
+		if err != nil {
+			logger.Error("failed to rotate due gameweeks", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate gameweeks", "rotated": rotated})
+			return
+		}
+		for _, gw := range rotated {
+			logger.Info("gameweek started",
+				zap.String("auction_id", gw.AuctionID.Hex()),
+				zap.Int("gameweek", gw.Number),
+			)
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Due gameweeks rotated successfully",
+			"rotated": rotated,
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// SaveFranchiseController adds a franchise to the registry of a series, or
+// replaces the name and aliases of the one with the same short name.
+func SaveFranchiseController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			SeriesID  int      `json:"series_id"`
+			ShortName string   `json:"short_name" binding:"required"`
+			Name      string   `json:"name" binding:"required"`
+			Aliases   []string `json:"aliases"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		shortName := strings.ToUpper(strings.TrimSpace(request.ShortName))
+		if shortName == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "short_name is required"})
+			return
+		}
+		aliases := make([]string, 0, len(request.Aliases))
+		for _, a := range request.Aliases {
+			if a = strings.TrimSpace(a); a != "" {
+				aliases = append(aliases, a)
+			}
+		}
+
+		now := time.Now()
+		var saved models.Franchise
+		err := db.Collection(constants.FranchiseCollection).FindOneAndUpdate(ctx,
+			bson.M{"series_id": request.SeriesID, "short_name": shortName},
+			bson.M{
+				"$set": bson.M{
+					"name":       strings.TrimSpace(request.Name),
+					"aliases":    aliases,
+					"updated_at": now,
+				},
+				"$setOnInsert": bson.M{"created_at": now},
+			},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&saved)
+		if err != nil {
+			logger.Error("failed to save franchise", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save franchise"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":   "Franchise saved successfully",
+			"franchise": saved,
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/h2h"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// SetupH2HController switches an auction to head-to-head mode and generates
+// its round-robin fixtures from start_gameweek (the current gameweek by
+// default). Fixtures before start_gameweek are kept. Rules missing from the
+// request keep their current value.
+func SetupH2HController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID     primitive.ObjectID `json:"auction_id" binding:"required"`
+			StartGameweek *int               `json:"start_gameweek"`
+			Cycles        int                `json:"cycles"`
+			Rules         h2h.Rules          `json:"rules"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		// Bind the auction ID first so the current rules can be used as the
+		// base that the request body is decoded over.
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		var auction models.Auction
+		err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		rules, err := h2h.LoadRules(ctx, db, request.AuctionID)
+		if err != nil {
+			logger.Error("failed to fetch h2h rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		request.Rules = rules
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		if request.Cycles == 0 {
+			request.Cycles = 1
+		}
+		if request.Cycles < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cycles must be >= 1"})
+			return
+		}
+		start := auction.CurrentGameweek
+		if request.StartGameweek != nil {
+			start = *request.StartGameweek
+		}
+		if start < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start_gameweek must be >= 0"})
+			return
+		}
+
+		fixtures, err := h2h.Generate(ctx, db, request.AuctionID, start, request.Cycles)
+		if err != nil {
+			logger.Error("failed to generate h2h fixtures", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate fixtures"})
+			return
+		}
+		if len(fixtures) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least two teams are needed for head-to-head"})
+			return
+		}
+
+		_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+			bson.M{"_id": request.AuctionID},
+			bson.M{"$set": bson.M{
+				"league_mode": models.LeagueModeH2H,
+				"h2h_rules":   request.Rules,
+				"updated_at":  time.Now(),
+			}},
+		)
+		if err != nil {
+			logger.Error("failed to enable h2h mode", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable head-to-head mode"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Head-to-head fixtures generated successfully",
+			"rules":    request.Rules,
+			"fixtures": fixtures,
+		})
+	}
+}
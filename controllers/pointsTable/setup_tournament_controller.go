@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/tournament"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// SetupTournamentController sets the club tournament rules of an auction
+// and, when cycles is given, replaces its unplayed fixtures with a
+// round-robin schedule between its teams. Rules missing from the request
+// keep their current value.
+func SetupTournamentController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Cycles    int                `json:"cycles"`
+			Overs     int                `json:"overs"`
+			Rules     tournament.Rules   `json:"rules"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		// Bind the auction ID first so the current rules can be used as the
+		// base that the request body is decoded over.
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		rules, err := tournament.LoadRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch tournament rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		request.Rules = rules
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		if err := request.Rules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if request.Cycles < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cycles must be >= 1"})
+			return
+		}
+		if request.Overs < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "overs must be >= 1"})
+			return
+		}
+
+		_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+			bson.M{"_id": request.AuctionID},
+			bson.M{"$set": bson.M{
+				"tournament_rules": request.Rules,
+				"updated_at":       time.Now(),
+			}},
+		)
+		if err != nil {
+			logger.Error("failed to save tournament rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save tournament rules"})
+			return
+		}
+
+		if request.Cycles == 0 {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Tournament rules updated successfully",
+				"rules":   request.Rules,
+			})
+			return
+		}
+
+		fixtures, err := tournament.Generate(ctx, db, request.AuctionID, request.Cycles, request.Overs)
+		if err != nil {
+			logger.Error("failed to generate tournament fixtures", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate fixtures"})
+			return
+		}
+		if len(fixtures) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "At least two teams are needed for a tournament"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Tournament fixtures generated successfully",
+			"rules":    request.Rules,
+			"fixtures": fixtures,
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/scoring"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// StartLiveMatchController starts ball-by-ball scoring of a club match
+// between two teams of an auction. Any squad player can bat or bowl.
+func StartLiveMatchController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID    primitive.ObjectID `json:"auction_id" binding:"required"`
+			Team1ID      primitive.ObjectID `json:"team1_id" binding:"required"`
+			Team2ID      primitive.ObjectID `json:"team2_id" binding:"required"`
+			BattingFirst primitive.ObjectID `json:"batting_first" binding:"required"`
+			Overs        int                `json:"overs" binding:"required,min=1"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+		if request.Team1ID == request.Team2ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team1_id and team2_id must differ"})
+			return
+		}
+		if request.BattingFirst != request.Team1ID && request.BattingFirst != request.Team2ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "batting_first must be team1_id or team2_id"})
+			return
+		}
+
+		now := time.Now()
+		lm := models.LiveMatch{
+			AuctionID:    request.AuctionID,
+			Team1ID:      request.Team1ID,
+			Team2ID:      request.Team2ID,
+			BattingFirst: request.BattingFirst,
+			Overs:        request.Overs,
+			Status:       models.LiveMatchInProgress,
+			Events:       []models.LiveEvent{},
+			CreatedBy:    c.GetString("email"),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		match, err := scoring.MatchOf(ctx, db, &lm)
+		if err != nil {
+			liveMatchError(c, logger, err)
+			return
+		}
+
+		res, err := db.Collection(constants.LiveMatchCollection).InsertOne(ctx, lm)
+		if err != nil {
+			logger.Error("failed to insert live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start match"})
+			return
+		}
+		lm.ID = res.InsertedID.(primitive.ObjectID)
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message":    "Live match started successfully",
+			"live_match": lm,
+			"state":      scoring.New(match),
+		})
+	}
+}
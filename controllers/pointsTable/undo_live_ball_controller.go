@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/scoring"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UndoLiveBallController takes back the last ball of a live match and the
+// events recorded after it. Undoing the last ball of a finished match
+// reopens it; finishing it again rescores the same manual match.
+func UndoLiveBallController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			LiveMatchID primitive.ObjectID `json:"live_match_id" binding:"required"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		lm, match, err := loadLiveMatch(ctx, db, request.LiveMatchID)
+		if err != nil {
+			liveMatchError(c, logger, err)
+			return
+		}
+		events, err := scoring.Undo(lm.Events)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		state, err := scoring.Replay(match, events)
+		if err != nil {
+			logger.Error("failed to replay live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay match"})
+			return
+		}
+		lm.Events = events
+
+		if _, err := saveLiveMatch(ctx, logger, db, lm, state); errors.Is(err, errLiveMatchChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Match was updated by another scorer, reload it"})
+			return
+		} else if err != nil {
+			logger.Error("failed to save live match", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save match"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "Ball undone successfully",
+			"live_match": lm,
+			"state":      state,
+		})
+	}
+}
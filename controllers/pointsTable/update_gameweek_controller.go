@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UpdateGameweekController changes the times or fixtures of a gameweek that
+// has not started. Omitted fields keep their value.
+func UpdateGameweekController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			GameweekID   primitive.ObjectID `json:"gameweek_id" binding:"required"`
+			StartTime    *time.Time         `json:"start_time"`
+			LockDeadline *time.Time         `json:"lock_deadline"`
+			Fixtures     *[]models.Fixture  `json:"fixtures"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			logger.Error("failed to bind request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		gameweeks := db.Collection(constants.GameweekCollection)
+		var gw models.Gameweek
+		err := gameweeks.FindOne(ctx, bson.M{"_id": request.GameweekID}).Decode(&gw)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Gameweek not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+		if gw.Rotated {
+			c.JSON(http.StatusConflict, gin.H{"error": "Gameweek has already started"})
+			return
+		}
+
+		if request.StartTime != nil {
+			gw.StartTime = *request.StartTime
+		}
+		if request.LockDeadline != nil {
+			gw.LockDeadline = *request.LockDeadline
+		}
+		if request.Fixtures != nil {
+			gw.Fixtures = *request.Fixtures
+		}
+		if err := normalizeGameweek(&gw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		gw.UpdatedAt = time.Now()
+
+		res, err := gameweeks.UpdateOne(ctx,
+			bson.M{"_id": gw.ID, "rotated": false},
+			bson.M{"$set": bson.M{
+				"start_time":    gw.StartTime,
+				"lock_deadline": gw.LockDeadline,
+				"fixtures":      gw.Fixtures,
+				"updated_at":    gw.UpdatedAt,
+			}},
+		)
+		if err != nil {
+			logger.Error("failed to update gameweek", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update gameweek"})
+			return
+		}
+		if res.MatchedCount == 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Gameweek has already started"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":  "Gameweek updated successfully",
+			"gameweek": gw,
+		})
+	}
+}
@@ -3,12 +3,17 @@ package controllers
 import (
 	"context"
 	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/gameweek"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -18,8 +23,14 @@ func UpdateMatchPointsController(logger *zap.Logger, db *mongo.Database) gin.Han
 			MatchIndex int `json:"match_index"`
 			Updates    []struct {
 				MatchID primitive.ObjectID `json:"match_id"`
-				Points  int               `json:"points"`
+				Points  int                `json:"points"`
 			} `json:"updates" binding:"required"`
+
+			// Optional: the real match these points come from. When given,
+			// the applied points are recorded in the points ledger.
+			AuctionID       primitive.ObjectID `json:"auction_id"`
+			Provider        string             `json:"provider"`
+			ProviderMatchID int                `json:"provider_match_id"`
 		}
 
 		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
@@ -42,6 +53,10 @@ func UpdateMatchPointsController(logger *zap.Logger, db *mongo.Database) gin.Han
 			matchIDs = append(matchIDs, u.MatchID)
 		}
 
+		// Points count for the XI of the gameweek being played: start any
+		// gameweek that is due first.
+		rotateAuctionOf(ctx, logger, db, request.AuctionID, matchIDs)
+
 		// 2. Batch fetch all current match docs
 		mCursor, err := db.Collection(constants.MatchCollection).Find(ctx,
 			bson.M{"_id": bson.M{"$in": matchIDs}},
@@ -52,14 +67,7 @@ func UpdateMatchPointsController(logger *zap.Logger, db *mongo.Database) gin.Han
 			return
 		}
 
-		type matchDoc struct {
-			ID                primitive.ObjectID `bson:"_id"`
-			Matches           []int              `bson:"matches"`
-			CurrentX1         bool               `bson:"currentX1"`
-			PrevEarnedPoints  int                `bson:"prevEarnedPoints"`
-			PrevBenchedPoints int                `bson:"prevBenchedPoints"`
-		}
-		var docs []matchDoc
+		var docs []pointsMatchDoc
 		mCursor.All(ctx, &docs)
 		mCursor.Close(ctx)
 
@@ -117,6 +125,96 @@ func UpdateMatchPointsController(logger *zap.Logger, db *mongo.Database) gin.Han
 			}
 		}
 
+		if !request.AuctionID.IsZero() && request.ProviderMatchID > 0 {
+			if request.Provider == "" {
+				request.Provider = cricbuzz.ProviderName
+			}
+			if err := recordAppliedPoints(ctx, db, request.AuctionID, request.Provider, request.ProviderMatchID, request.MatchIndex, docs, pointsReq); err != nil {
+				logger.Error("failed to record applied points", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Points saved but ledger update failed"})
+				return
+			}
+			// Not fatal: the points are applied, the binding is retried
+			// the next time the match is applied.
+			if err := bindExactMatches(ctx, db, request.AuctionID, request.Provider, request.ProviderMatchID); err != nil {
+				logger.Warn("failed to store cricbuzz player bindings", zap.Error(err))
+			}
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "Points updated successfully"})
 	}
 }
+
+// rotateAuctionOf starts any due gameweek of the auction, found from its
+// players' Match documents when auctionID is not given. Failures are logged:
+// the points are still applied to the XI as it stands.
+func rotateAuctionOf(ctx context.Context, logger *zap.Logger, db *mongo.Database, auctionID primitive.ObjectID, matchIDs []primitive.ObjectID) {
+	if auctionID.IsZero() && len(matchIDs) > 0 {
+		var player models.Player
+		err := db.Collection(constants.PlayerCollection).FindOne(ctx,
+			bson.M{"match": bson.M{"$in": matchIDs}},
+			options.FindOne().SetProjection(bson.M{"auction_id": 1}),
+		).Decode(&player)
+		if err != nil && err != mongo.ErrNoDocuments {
+			logger.Error("failed to find auction of matches", zap.Error(err))
+		}
+		auctionID = player.AuctionId
+	}
+	if auctionID.IsZero() {
+		return
+	}
+	if _, err := gameweek.RotateAuction(ctx, db, auctionID, time.Now()); err != nil {
+		logger.Error("failed to rotate due gameweeks", zap.Error(err))
+	}
+}
+
+type pointsMatchDoc struct {
+	ID                primitive.ObjectID `bson:"_id"`
+	Matches           []int              `bson:"matches"`
+	CurrentX1         bool               `bson:"currentX1"`
+	PrevEarnedPoints  int                `bson:"prevEarnedPoints"`
+	PrevBenchedPoints int                `bson:"prevBenchedPoints"`
+}
+
+// recordAppliedPoints marks the ledger entries of a real match as applied,
+// remembering the gameweek, the match slot and whether each player's points
+// counted as earned or benched.
+func recordAppliedPoints(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, providerName string, providerMatchID, matchIndex int, docs []pointsMatchDoc, points map[primitive.ObjectID]int) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	ops := make([]mongo.WriteModel, 0, len(docs))
+	for _, d := range docs {
+		countedAs := models.CountedBenched
+		if d.CurrentX1 {
+			countedAs = models.CountedEarned
+		}
+		ops = append(ops, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"auction_id":        auctionID,
+				"provider":          providerName,
+				"provider_match_id": providerMatchID,
+				"match_id":          d.ID,
+			}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"applied":     true,
+				"points":      points[d.ID],
+				"match_index": matchIndex,
+				"gameweek":    auction.CurrentGameweek,
+				"counted_as":  countedAs,
+				"applied_at":  now,
+			}}).
+			SetUpsert(true))
+	}
+
+	_, err = db.Collection(constants.LedgerCollection).BulkWrite(ctx, ops)
+	return err
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/services/fantasy"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UpdateScoringRulesController saves the fantasy scoring rules of an auction.
+// Rules missing from the request keep their current value.
+func UpdateScoringRulesController(logger *zap.Logger, db *mongo.Database) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Rules     fantasy.Rules      `json:"rules"`
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		// Bind the auction ID first so the current rules can be used as the
+		// base that the request body is decoded over.
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		rules, err := loadScoringRules(ctx, db, request.AuctionID)
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		if err != nil {
+			logger.Error("failed to fetch scoring rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+			return
+		}
+
+		request.Rules = rules
+		if err := c.ShouldBindBodyWithJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+
+		switch request.Rules.SubstituteFielding {
+		case fantasy.SubstituteFieldingCredit, fantasy.SubstituteFieldingSkip:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "substitute_fielding must be credit or skip"})
+			return
+		}
+		switch request.Rules.SuperOver {
+		case fantasy.SuperOverIgnore, fantasy.SuperOverCount:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "super_over must be ignore or count"})
+			return
+		}
+		switch request.Rules.ReducedOvers {
+		case fantasy.ReducedOversScale, fantasy.ReducedOversFull:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "reduced_overs must be scale or full"})
+			return
+		}
+		switch request.Rules.NoResult {
+		case fantasy.NoResultScore, fantasy.NoResultNoBonus, fantasy.NoResultVoid:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "no_result must be score, no_bonus or void"})
+			return
+		}
+		if request.Rules.Format != "" && fantasy.NormalizeFormat(request.Rules.Format) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be T20, ODI or TEST"})
+			return
+		}
+		request.Rules.Format = fantasy.NormalizeFormat(request.Rules.Format)
+		if request.Rules.ScheduledOvers < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduled_overs cannot be negative"})
+			return
+		}
+
+		_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+			bson.M{"_id": request.AuctionID},
+			bson.M{"$set": bson.M{
+				"scoring_rules": request.Rules,
+				"updated_at":    time.Now(),
+			}},
+		)
+		if err != nil {
+			logger.Error("failed to update scoring rules", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update scoring rules"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Scoring rules updated successfully",
+			"rules":   request.Rules,
+		})
+	}
+}
@@ -3,16 +3,30 @@ package constants
 import "time"
 
 var (
-	Err               = "err"
-	DBTimeout         = 10 * time.Second
-	MaxRetries        = 3
-	EmailKey          = "email"
-	UserCollection    = "users"
-	AuctionCollection = "auctions"
-	ProfileCollection = "profiles"
-	PlayerCollection  = "players"
-	TeamCollection    = "teams"
-	MatchCollection   = "matches"
-	OtpCollection     = "otps"
-	TeamPurse         = 100.00
+	Err                     = "err"
+	DBTimeout               = 10 * time.Second
+	MaxRetries              = 3
+	EmailKey                = "email"
+	UserCollection          = "users"
+	AuctionCollection       = "auctions"
+	ProfileCollection       = "profiles"
+	PlayerCollection        = "players"
+	TeamCollection          = "teams"
+	MatchCollection         = "matches"
+	OtpCollection           = "otps"
+	ReviewCollection        = "match_reviews"
+	ScorecardCollection     = "scorecards"
+	LedgerCollection        = "points_ledger"
+	RecomputeCollection     = "recompute_jobs"
+	GameweekCollection      = "gameweeks"
+	XISnapshotCollection    = "xi_snapshots"
+	LeaderboardCollection   = "leaderboard_snapshots"
+	H2HFixtureCollection    = "h2h_fixtures"
+	FranchiseCollection     = "franchises"
+	SeasonCollection        = "seasons"
+	SeasonFixtureCollection = "season_fixtures"
+	LiveMatchCollection     = "live_matches"
+	ClubFixtureCollection   = "club_fixtures"
+	CounterCollection       = "counters"
+	TeamPurse               = 100.00
 )
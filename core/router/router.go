@@ -1,13 +1,15 @@
 package router
 
 import (
-	auth "cric-auction-monolith/controllers/auth"
 	auction "cric-auction-monolith/controllers/auction"
+	auth "cric-auction-monolith/controllers/auth"
 	bidding "cric-auction-monolith/controllers/bidding"
 	players "cric-auction-monolith/controllers/player"
 	pointsTable "cric-auction-monolith/controllers/pointsTable"
 	profile "cric-auction-monolith/controllers/profile"
+	"cric-auction-monolith/core/constants"
 	"cric-auction-monolith/pkg/middlewares"
+	"cric-auction-monolith/services/provider"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,6 +21,11 @@ import (
 func NewGinRouter(logger *zap.Logger, db *mongo.Database) (router *gin.Engine) {
 	router = gin.Default()
 
+	scores, err := provider.NewFromEnv(logger)
+	if err != nil {
+		logger.Fatal("failed to create score provider", zap.Any(constants.Err, err))
+	}
+
 	router.Use(gin.Recovery())
 	router.Use(middlewares.CORSMiddleware)
 
@@ -39,6 +46,13 @@ func NewGinRouter(logger *zap.Logger, db *mongo.Database) (router *gin.Engine) {
 		authGroup.POST("/lotp", auth.LoginOtpController(logger, db))
 	}
 
+	// Called by an external cron, authenticated with CRON_SECRET rather than
+	// a user token.
+	cronGroup := router.Group("/api/v1/cron")
+	{
+		cronGroup.GET("/gameweeks/rotate", pointsTable.RotateDueGameweeksController(logger, db))
+	}
+
 	api := router.Group("/api/v1")
 	api.Use(middlewares.VerifyToken(logger))
 	profileGroup := api.Group("/profile")
@@ -83,6 +97,16 @@ func NewGinRouter(logger *zap.Logger, db *mongo.Database) (router *gin.Engine) {
 		playersGroup.POST("/eleven/get", players.GetElevenController(logger, db))
 
 		playersGroup.POST("/eleven/save", players.SaveElevenController(logger, db))
+
+		playersGroup.POST("/eleven/validate", players.ValidateElevenController(logger, db))
+
+		playersGroup.POST("/eleven/suggest", players.SuggestElevenController(logger, db))
+
+		playersGroup.POST("/fixtures", players.GetPlayerFixturesController(logger, db))
+
+		playersGroup.POST("/eleven/rules", players.GetElevenRulesController(logger, db))
+
+		playersGroup.PATCH("/eleven/rules", players.UpdateElevenRulesController(logger, db))
 	}
 
 	pointsTableGroup := api.Group("/points-table")
@@ -92,12 +116,47 @@ func NewGinRouter(logger *zap.Logger, db *mongo.Database) (router *gin.Engine) {
 		pointsTableGroup.PATCH("/match-points", pointsTable.UpdateMatchPointsController(logger, db))
 		pointsTableGroup.POST("/leaderboard", pointsTable.GetLeaderboardController(logger, db))
 		pointsTableGroup.POST("/scoreboard", pointsTable.GetScoreboardController(logger, db))
+		pointsTableGroup.POST("/awards", pointsTable.GetAwardsController(logger, db))
+		pointsTableGroup.POST("/player-stats", pointsTable.GetPlayerStatsController(logger, db))
+		pointsTableGroup.POST("/points/explain", pointsTable.ExplainPointsController(logger, db))
 		pointsTableGroup.POST("/change-xi", pointsTable.ChangeXIController(logger, db))
 		pointsTableGroup.POST("/team-details", pointsTable.GetTeamDetailsController(logger, db))
 		pointsTableGroup.POST("/rollback-xi", pointsTable.RollbackXIController(logger, db))
+		pointsTableGroup.POST("/rollback-xi/preview", pointsTable.PreviewRollbackXIController(logger, db))
+		pointsTableGroup.POST("/xi-history", pointsTable.GetXIHistoryController(logger, db))
 		pointsTableGroup.POST("/reset-points", pointsTable.ResetPointsController(logger, db))
-		pointsTableGroup.GET("/cricbuzz/matches", pointsTable.CricbuzzMatchesController(logger, db))
-		pointsTableGroup.POST("/cricbuzz/calculate-points", pointsTable.CricbuzzPointsController(logger, db))
+		pointsTableGroup.GET("/cricbuzz/matches", pointsTable.CricbuzzMatchesController(logger, db, scores))
+		pointsTableGroup.POST("/cricbuzz/calculate-points", pointsTable.CricbuzzPointsController(logger, db, scores))
+		pointsTableGroup.GET("/cricbuzz/quota", pointsTable.CricbuzzQuotaController(logger, db, scores))
+		pointsTableGroup.PATCH("/cricbuzz/player-binding", pointsTable.BindCricbuzzPlayerController(logger, db))
+		pointsTableGroup.POST("/reviews", pointsTable.GetMatchReviewsController(logger, db))
+		pointsTableGroup.PATCH("/reviews/resolve", pointsTable.ResolveMatchReviewController(logger, db))
+		pointsTableGroup.POST("/scorecards/recalculate", pointsTable.RecalculateStoredMatchController(logger, db))
+		pointsTableGroup.POST("/scorecards/manual", pointsTable.ManualScorecardController(logger, db))
+		pointsTableGroup.POST("/live", pointsTable.GetLiveMatchController(logger, db))
+		pointsTableGroup.POST("/live/start", pointsTable.StartLiveMatchController(logger, db))
+		pointsTableGroup.POST("/live/event", pointsTable.RecordLiveEventController(logger, db))
+		pointsTableGroup.POST("/live/undo", pointsTable.UndoLiveBallController(logger, db))
+		pointsTableGroup.POST("/dismissals/parse", pointsTable.ParseDismissalsController(logger, db))
+		pointsTableGroup.POST("/franchises", pointsTable.GetFranchisesController(logger, db))
+		pointsTableGroup.POST("/franchises/save", pointsTable.SaveFranchiseController(logger, db))
+		pointsTableGroup.DELETE("/franchises", pointsTable.DeleteFranchiseController(logger, db))
+		pointsTableGroup.POST("/scoring-rules", pointsTable.GetScoringRulesController(logger, db))
+		pointsTableGroup.PATCH("/scoring-rules", pointsTable.UpdateScoringRulesController(logger, db))
+		pointsTableGroup.POST("/recompute", pointsTable.RecomputePointsController(logger, db))
+		pointsTableGroup.POST("/recompute/apply", pointsTable.ApplyRecomputeController(logger, db))
+		pointsTableGroup.POST("/gameweeks", pointsTable.GetGameweeksController(logger, db))
+		pointsTableGroup.POST("/gameweeks/create", pointsTable.CreateGameweekController(logger, db))
+		pointsTableGroup.PATCH("/gameweeks", pointsTable.UpdateGameweekController(logger, db))
+		pointsTableGroup.DELETE("/gameweeks", pointsTable.DeleteGameweekController(logger, db))
+		pointsTableGroup.POST("/season", pointsTable.GetSeasonController(logger, db))
+		pointsTableGroup.POST("/season/import", pointsTable.ImportSeasonController(logger, db, scores))
+		pointsTableGroup.POST("/h2h", pointsTable.GetH2HController(logger, db))
+		pointsTableGroup.POST("/h2h/setup", pointsTable.SetupH2HController(logger, db))
+		pointsTableGroup.POST("/tournament", pointsTable.GetTournamentController(logger, db))
+		pointsTableGroup.POST("/tournament/setup", pointsTable.SetupTournamentController(logger, db))
+		pointsTableGroup.POST("/tournament/fixtures", pointsTable.CreateClubFixtureController(logger, db))
+		pointsTableGroup.PATCH("/tournament/result", pointsTable.RecordClubResultController(logger, db))
 	}
 
 	biddingGroup := api.Group("/bidding")
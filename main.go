@@ -8,6 +8,8 @@ import (
 	"cric-auction-monolith/core/logger"
 	"cric-auction-monolith/core/router"
 	"cric-auction-monolith/pkg/utils"
+	"cric-auction-monolith/services/franchise"
+	"cric-auction-monolith/services/gameweek"
 
 	"go.uber.org/zap"
 )
@@ -26,7 +28,18 @@ func main() {
 	defer database.DisconnectMongoClient(ctx, client, logger)
 	db := client.Database(cfg.DbName)
 
+	// Auctions without a series play the IPL: make sure its franchises are
+	// in the registry.
+	if err := franchise.SeedIPL(ctx, db); err != nil {
+		logger.Warn("failed to seed IPL franchises", zap.Error(err))
+	}
+
 	router := router.NewGinRouter(logger, db)
 
+	// Start gameweeks in the background as their start time passes.
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go gameweek.NewScheduler(logger, db).Run(schedulerCtx)
+
 	utils.StartServer(ctx, router, logger)
 }
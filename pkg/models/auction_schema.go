@@ -6,16 +6,26 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// League modes. Auctions without a mode rank teams on total points.
+const (
+	LeagueModePoints = "points"
+	LeagueModeH2H    = "h2h"
+)
+
 type Auction struct {
-	ID           primitive.ObjectID `bson:"_id" json:"id"`
-	AuctionName  string             `bson:"auction_name" json:"auction_name"`
-	AuctionImage string             `bson:"auction_image" json:"auction_image"`
-	CreatedBy    string             `bson:"created_by" json:"created_by"`
-	AuctionDate  time.Time          `bson:"auction_date" json:"auction_date"`
-	IsIPLAuction bool               `bson:"is_ipl_auction" json:"is_ipl_auction"`
-	BasePrice    float64            `bson:"base_price" json:"base_price"`
-	Purse        float64            `bson:"purse" json:"purse"`
-	JoinedBy     []string           `bson:"joined_by" json:"joined_by"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID              primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionName     string             `bson:"auction_name" json:"auction_name"`
+	AuctionImage    string             `bson:"auction_image" json:"auction_image"`
+	CreatedBy       string             `bson:"created_by" json:"created_by"`
+	AuctionDate     time.Time          `bson:"auction_date" json:"auction_date"`
+	IsIPLAuction    bool               `bson:"is_ipl_auction" json:"is_ipl_auction"`
+	SeriesID        int                `bson:"series_id,omitempty" json:"series_id,omitempty"` // Cricbuzz series; 0 for the IPL
+	SeriesName      string             `bson:"series_name,omitempty" json:"series_name,omitempty"`
+	BasePrice       float64            `bson:"base_price" json:"base_price"`
+	Purse           float64            `bson:"purse" json:"purse"`
+	JoinedBy        []string           `bson:"joined_by" json:"joined_by"`
+	CurrentGameweek int                `bson:"current_gameweek" json:"current_gameweek"`
+	LeagueMode      string             `bson:"league_mode,omitempty" json:"league_mode,omitempty"`
+	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
 }
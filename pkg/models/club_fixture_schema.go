@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Club match outcomes.
+const (
+	OutcomeTeam1    = "team1"
+	OutcomeTeam2    = "team2"
+	OutcomeTie      = "tie"
+	OutcomeNoResult = "no_result"
+)
+
+// ClubFixture is a real match between two teams of an auction in its club
+// tournament. Result is nil until the match is played.
+type ClubFixture struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Round     int                `bson:"round" json:"round"`
+	Team1ID   primitive.ObjectID `bson:"team1_id" json:"team1_id"`
+	Team2ID   primitive.ObjectID `bson:"team2_id" json:"team2_id"`
+	StartTime time.Time          `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	Venue     string             `bson:"venue,omitempty" json:"venue,omitempty"`
+	Overs     int                `bson:"overs" json:"overs"` // per innings
+	Result    *ClubResult        `bson:"result,omitempty" json:"result,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ClubResult is the outcome of a club fixture with each team's innings.
+type ClubResult struct {
+	Outcome     string             `bson:"outcome" json:"outcome"`
+	Team1       ClubInnings        `bson:"team1" json:"team1"`
+	Team2       ClubInnings        `bson:"team2" json:"team2"`
+	LiveMatchID primitive.ObjectID `bson:"live_match_id,omitempty" json:"live_match_id,omitempty"`
+	RecordedAt  time.Time          `bson:"recorded_at" json:"recorded_at"`
+}
+
+// ClubInnings is a team's batting in a club match. Overs are in the 12.4
+// notation.
+type ClubInnings struct {
+	Runs    int    `bson:"runs" json:"runs"`
+	Wickets int    `bson:"wickets" json:"wickets"`
+	Overs   string `bson:"overs" json:"overs"`
+	AllOut  bool   `bson:"all_out" json:"all_out"`
+}
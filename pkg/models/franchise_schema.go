@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Franchise is a team of a Cricbuzz series with the other names it appears
+// under on scorecards, e.g. "CSK" for "Chennai Super Kings" or "Chennai".
+// IPL franchises have SeriesID 0, the series of auctions without one.
+type Franchise struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SeriesID  int                `bson:"series_id" json:"series_id"`
+	ShortName string             `bson:"short_name" json:"short_name"`
+	Name      string             `bson:"name" json:"name"`
+	Aliases   []string           `bson:"aliases" json:"aliases"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Gameweek is one scoring period of an auction. Playing XI edits close at
+// LockDeadline and the saved XI becomes the current XI at StartTime.
+type Gameweek struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID    primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Number       int                `bson:"number" json:"number"`
+	StartTime    time.Time          `bson:"start_time" json:"start_time"`
+	LockDeadline time.Time          `bson:"lock_deadline" json:"lock_deadline"`
+	Fixtures     []Fixture          `bson:"fixtures" json:"fixtures"`
+	Rotated      bool               `bson:"rotated" json:"rotated"`
+	RotatedAt    time.Time          `bson:"rotated_at,omitempty" json:"rotated_at,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Fixture is a real match played in a gameweek.
+type Fixture struct {
+	Provider        string    `bson:"provider,omitempty" json:"provider,omitempty"`
+	ProviderMatchID int       `bson:"provider_match_id,omitempty" json:"provider_match_id,omitempty"`
+	Teams           []string  `bson:"teams" json:"teams"`
+	StartTime       time.Time `bson:"start_time" json:"start_time"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// H2HFixture is a head-to-head matchup between two fantasy teams in a
+// gameweek. AwayTeamID is zero when the home team has a bye.
+type H2HFixture struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID  primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Gameweek   int                `bson:"gameweek" json:"gameweek"`
+	HomeTeamID primitive.ObjectID `bson:"home_team_id" json:"home_team_id"`
+	AwayTeamID primitive.ObjectID `bson:"away_team_id,omitempty" json:"away_team_id,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LeaderboardSnapshot is the leaderboard of an auction as it stood at the
+// close of a gameweek.
+type LeaderboardSnapshot struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Gameweek  int                `bson:"gameweek" json:"gameweek"`
+	Teams     []LeaderboardRow   `bson:"teams" json:"teams"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// LeaderboardRow is one team's standing.
+type LeaderboardRow struct {
+	TeamID        primitive.ObjectID `bson:"team_id" json:"team_id"`
+	TeamName      string             `bson:"team_name" json:"team_name"`
+	TeamImage     string             `bson:"team_image" json:"team_image"`
+	Rank          int                `bson:"rank" json:"rank"`
+	EarnedPoints  int                `bson:"earned_points" json:"earned_points"`
+	BenchedPoints int                `bson:"benched_points" json:"benched_points"`
+	TotalPoints   int                `bson:"total_points" json:"total_points"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Live match states.
+const (
+	LiveMatchInProgress = "in_progress"
+	LiveMatchComplete   = "complete"
+)
+
+// Live scoring event kinds.
+const (
+	LiveEventBatter     = "batter"      // a batter comes in
+	LiveEventBowler     = "bowler"      // a bowler starts an over
+	LiveEventBall       = "ball"        // a delivery
+	LiveEventRetire     = "retire"      // a batter retires between deliveries
+	LiveEventEndInnings = "end_innings" // the innings is closed early
+)
+
+// Delivery extras.
+const (
+	ExtraWide   = "wide"
+	ExtraNoBall = "no_ball"
+	ExtraBye    = "bye"
+	ExtraLegBye = "leg_bye"
+)
+
+// LiveMatch is a club match between two auction teams scored ball by ball.
+// The cards are derived from the event log, so undoing a ball only drops
+// events.
+type LiveMatch struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID    primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Team1ID      primitive.ObjectID `bson:"team1_id" json:"team1_id"`
+	Team2ID      primitive.ObjectID `bson:"team2_id" json:"team2_id"`
+	BattingFirst primitive.ObjectID `bson:"batting_first" json:"batting_first"`
+	Overs        int                `bson:"overs" json:"overs"`
+	Status       string             `bson:"status" json:"status"`
+	Events       []LiveEvent        `bson:"events" json:"events"`
+	// ProviderMatchID is the manual match the finished scorecard is
+	// stored as.
+	ProviderMatchID int       `bson:"provider_match_id,omitempty" json:"provider_match_id,omitempty"`
+	CreatedBy       string    `bson:"created_by" json:"created_by"`
+	CreatedAt       time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time `bson:"updated_at" json:"updated_at"`
+	CompletedAt     time.Time `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// LiveEvent is one entry of a live match's scoring log.
+type LiveEvent struct {
+	Kind     string             `bson:"kind" json:"kind"`
+	PlayerID primitive.ObjectID `bson:"player_id,omitempty" json:"player_id,omitempty"` // batter or bowler
+	// Runs off the bat. Byes, leg byes and runs run on a wide are
+	// ExtraRuns; the one-run wide and no-ball penalty is added on top.
+	Runs      int         `bson:"runs,omitempty" json:"runs,omitempty"`
+	Extra     string      `bson:"extra,omitempty" json:"extra,omitempty"`
+	ExtraRuns int         `bson:"extra_runs,omitempty" json:"extra_runs,omitempty"`
+	Boundary  bool        `bson:"boundary,omitempty" json:"boundary,omitempty"` // four runs off the bat reached the rope
+	Wicket    *LiveWicket `bson:"wicket,omitempty" json:"wicket,omitempty"`
+	At        time.Time   `bson:"at" json:"at"`
+}
+
+// LiveWicket is a dismissal on a delivery. Kind is a dismissal type name,
+// e.g. "caught" or "run_out".
+type LiveWicket struct {
+	Kind        string             `bson:"kind" json:"kind"`
+	PlayerOutID primitive.ObjectID `bson:"player_out_id,omitempty" json:"player_out_id,omitempty"` // the striker when empty
+	FielderID   primitive.ObjectID `bson:"fielder_id,omitempty" json:"fielder_id,omitempty"`
+	Fielder2ID  primitive.ObjectID `bson:"fielder2_id,omitempty" json:"fielder2_id,omitempty"` // second fielder of a run out
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Review kinds and statuses for the player match review queue.
+const (
+	ReviewKindUnmatched     = "unmatched"
+	ReviewKindLowConfidence = "low_confidence"
+
+	ReviewPending    = "pending"
+	ReviewAccepted   = "accepted"
+	ReviewReassigned = "reassigned"
+	ReviewIgnored    = "ignored"
+)
+
+// MatchReview is a pending or resolved decision about how a Cricbuzz player
+// maps to an auction player. There is one review per auction and Cricbuzz
+// player (by ID, or by name when the scorecard has no ID for them).
+type MatchReview struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID         primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Kind              string             `bson:"kind" json:"kind"`
+	CricbuzzID        int                `bson:"cricbuzz_id" json:"cricbuzz_id"`
+	CricbuzzName      string             `bson:"cricbuzz_name" json:"cricbuzz_name"`
+	CricbuzzTeam      string             `bson:"cricbuzz_team" json:"cricbuzz_team"`
+	CricbuzzMatchIDs  []int              `bson:"cricbuzz_match_ids" json:"cricbuzz_match_ids"`
+	SuggestedPlayerID primitive.ObjectID `bson:"suggested_player_id,omitempty" json:"suggested_player_id,omitempty"`
+	SuggestedName     string             `bson:"suggested_name,omitempty" json:"suggested_name,omitempty"`
+	Confidence        float64            `bson:"confidence" json:"confidence"`
+	Status            string             `bson:"status" json:"status"`
+	ResolvedPlayerID  primitive.ObjectID `bson:"resolved_player_id,omitempty" json:"resolved_player_id,omitempty"`
+	ResolvedBy        string             `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	ResolvedAt        time.Time          `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
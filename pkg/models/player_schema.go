@@ -21,6 +21,7 @@ type Player struct {
 	IPLTeam           string             `bson:"ipl_team,omitempty" json:"ipl_team"`
 	PrevFantasyPoints int                `bson:"prev_fantasy_points,omitempty" json:"prev_fantasy_points,omitempty"`
 	Match             primitive.ObjectID `bson:"match,omitempty" json:"match,omitempty"`
+	CricbuzzID        int                `bson:"cricbuzz_id,omitempty" json:"cricbuzz_id,omitempty"`
 	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
 }
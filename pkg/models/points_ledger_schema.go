@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// How applied points counted for the owning team.
+const (
+	CountedEarned  = "earned"
+	CountedBenched = "benched"
+)
+
+// PointsEntry records the points of one auction player for one real match.
+// The calculated_* fields are written when the match is scored; the rest is
+// written when the points are applied to the player's Match document.
+type PointsEntry struct {
+	ID               primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	AuctionID        primitive.ObjectID     `bson:"auction_id" json:"auction_id"`
+	Provider         string                 `bson:"provider" json:"provider"`
+	ProviderMatchID  int                    `bson:"provider_match_id" json:"provider_match_id"`
+	MatchID          primitive.ObjectID     `bson:"match_id" json:"match_id"`
+	PlayerID         primitive.ObjectID     `bson:"player_id,omitempty" json:"player_id,omitempty"`
+	PlayerName       string                 `bson:"player_name,omitempty" json:"player_name,omitempty"`
+	IPLTeams         []string               `bson:"ipl_teams,omitempty" json:"ipl_teams,omitempty"`
+	CricbuzzID       int                    `bson:"cricbuzz_id,omitempty" json:"cricbuzz_id,omitempty"`
+	CricbuzzName     string                 `bson:"cricbuzz_name,omitempty" json:"cricbuzz_name,omitempty"`
+	ExactNameMatch   bool                   `bson:"exact_name_match,omitempty" json:"exact_name_match,omitempty"` // bound to CricbuzzID when applied
+	CalculatedPoints int                    `bson:"calculated_points" json:"calculated_points"`
+	Breakdown        fantasy.PointBreakdown `bson:"breakdown" json:"breakdown"`
+	CalculatedAt     time.Time              `bson:"calculated_at,omitempty" json:"calculated_at,omitempty"`
+	Applied          bool                   `bson:"applied" json:"applied"`
+	Points           int                    `bson:"points" json:"points"`
+	MatchIndex       int                    `bson:"match_index" json:"match_index"`
+	Gameweek         int                    `bson:"gameweek" json:"gameweek"`
+	CountedAs        string                 `bson:"counted_as,omitempty" json:"counted_as,omitempty"`
+	AppliedAt        time.Time              `bson:"applied_at,omitempty" json:"applied_at,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Recompute job statuses.
+const (
+	RecomputePending  = "pending"
+	RecomputeApplying = "applying" // claimed by an apply in progress
+	RecomputeApplied  = "applied"
+)
+
+// RecomputeJob is the diff report of replaying an auction's stored matches
+// through the current calculator. Nothing changes until it is applied.
+type RecomputeJob struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Status    string             `bson:"status" json:"status"`
+	Matches   int                `bson:"matches" json:"matches"`
+	Skipped   []string           `bson:"skipped" json:"skipped"`
+	Entries   []RecomputeEntry   `bson:"entries" json:"entries"`
+	Unmatched []RecomputeEntry   `bson:"unmatched" json:"unmatched"` // left unchanged until reviewed
+	Players   []RecomputePlayer  `bson:"players" json:"players"`
+	Teams     []RecomputeTeam    `bson:"teams" json:"teams"`
+	CreatedBy string             `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	AppliedBy string             `bson:"applied_by,omitempty" json:"applied_by,omitempty"`
+	AppliedAt time.Time          `bson:"applied_at,omitempty" json:"applied_at,omitempty"`
+}
+
+// RecomputeEntry is a changed ledger entry.
+type RecomputeEntry struct {
+	EntryID         primitive.ObjectID `bson:"entry_id" json:"entry_id"`
+	ProviderMatchID int                `bson:"provider_match_id" json:"provider_match_id"`
+	MatchID         primitive.ObjectID `bson:"match_id" json:"match_id"`
+	PlayerName      string             `bson:"player_name" json:"player_name"`
+	Gameweek        int                `bson:"gameweek" json:"gameweek"`
+	MatchIndex      int                `bson:"match_index" json:"match_index"`
+	CountedAs       string             `bson:"counted_as" json:"counted_as"`
+	OldPoints       int                `bson:"old_points" json:"old_points"`
+	NewPoints       int                `bson:"new_points" json:"new_points"`
+}
+
+// RecomputePlayer sums the changes of one player across matches.
+type RecomputePlayer struct {
+	MatchID    primitive.ObjectID `bson:"match_id" json:"match_id"`
+	PlayerName string             `bson:"player_name" json:"player_name"`
+	OldPoints  int                `bson:"old_points" json:"old_points"`
+	NewPoints  int                `bson:"new_points" json:"new_points"`
+}
+
+// RecomputeTeam sums the changes of one fantasy team.
+type RecomputeTeam struct {
+	TeamID     primitive.ObjectID `bson:"team_id" json:"team_id"`
+	TeamName   string             `bson:"team_name" json:"team_name"`
+	OldEarned  int                `bson:"old_earned" json:"old_earned"`
+	NewEarned  int                `bson:"new_earned" json:"new_earned"`
+	OldBenched int                `bson:"old_benched" json:"old_benched"`
+	NewBenched int                `bson:"new_benched" json:"new_benched"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"cric-auction-monolith/services/cricbuzz"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Scorecard is a scorecard as fetched from a score provider, kept so matches
+// can be audited and recalculated without calling the provider again.
+type Scorecard struct {
+	ID              primitive.ObjectID         `bson:"_id,omitempty" json:"id"`
+	Provider        string                     `bson:"provider" json:"provider"`
+	ProviderMatchID int                        `bson:"provider_match_id" json:"provider_match_id"`
+	AuctionID       primitive.ObjectID         `bson:"auction_id,omitempty" json:"auction_id,omitempty"` // owner of a manual scorecard
+	Hash            string                     `bson:"hash" json:"hash"`
+	FetchedAt       time.Time                  `bson:"fetched_at" json:"fetched_at"`
+	Scorecard       cricbuzz.ScorecardResponse `bson:"scorecard" json:"scorecard"`
+	CreatedAt       time.Time                  `bson:"created_at" json:"created_at"`
+	UpdatedAt       time.Time                  `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Season sources.
+const (
+	SeasonSourceProvider = "provider"
+	SeasonSourceCSV      = "csv"
+)
+
+// Season is the real tournament an auction is played on. Its fixture list
+// is kept in SeasonFixture documents.
+type Season struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID  primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Name       string             `bson:"name" json:"name"`
+	Source     string             `bson:"source" json:"source"`
+	Provider   string             `bson:"provider,omitempty" json:"provider,omitempty"`
+	SeriesID   int                `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	ImportedAt time.Time          `bson:"imported_at" json:"imported_at"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// SeasonFixture is one real match of a season.
+type SeasonFixture struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SeasonID        primitive.ObjectID `bson:"season_id" json:"season_id"`
+	AuctionID       primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	MatchDesc       string             `bson:"match_desc,omitempty" json:"match_desc,omitempty"`
+	StartTime       time.Time          `bson:"start_time" json:"start_time"`
+	Venue           string             `bson:"venue,omitempty" json:"venue,omitempty"`
+	Team1           string             `bson:"team1" json:"team1"`
+	Team2           string             `bson:"team2" json:"team2"`
+	Provider        string             `bson:"provider,omitempty" json:"provider,omitempty"`
+	ProviderMatchID int                `bson:"provider_match_id,omitempty" json:"provider_match_id,omitempty"`
+}
+
+// Teams returns the two teams of the fixture.
+func (f SeasonFixture) Teams() []string {
+	return []string{f.Team1, f.Team2}
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// XISnapshot is the state of every Match document of an auction at the end
+// of a gameweek, taken just before the XI was rotated into the next one.
+type XISnapshot struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Gameweek  int                `bson:"gameweek" json:"gameweek"`
+	Matches   []Match            `bson:"matches" json:"matches"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package cricbuzz
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is a small in-memory cache of response bodies keyed by URL. It
+// keeps the raw JSON rather than decoded values so every caller decodes its
+// own copy and may change it freely.
+type ttlCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+func newTTLCache() *ttlCache {
+	return &ttlCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *ttlCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+func (c *ttlCache) set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{body: body, expiresAt: time.Now().Add(ttl)}
+}
@@ -0,0 +1,39 @@
+package cricbuzz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCachedScorecardIsCopiedPerCaller(t *testing.T) {
+	requests := 0
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"ismatchcomplete": true, "status": "A won", "scorecard": [{"inningsid": 1, "score": 150}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", strings.TrimPrefix(srv.URL, "https://"))
+	c.http = srv.Client()
+
+	first, err := c.FetchScorecard(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("FetchScorecard: %v", err)
+	}
+	first.Status = "changed"
+	first.Scorecard[0].Score = 0
+
+	second, err := c.FetchScorecard(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("FetchScorecard: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1 with the second served from cache", requests)
+	}
+	if second == first || second.Status != "A won" || second.Scorecard[0].Score != 150 {
+		t.Errorf("cached scorecard shared with the first caller: %+v", second)
+	}
+}
@@ -1,85 +1,247 @@
 package cricbuzz
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// ProviderName identifies Cricbuzz as the source of match and player IDs.
+const ProviderName = "cricbuzz"
+
 type Client struct {
 	apiKey  string
 	apiHost string
 	http    *http.Client
+
+	maxAttempts  int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	scorecardTTL time.Duration
+	recentTTL    time.Duration
+
+	cache *ttlCache
+	quota quotaTracker
 }
 
-func NewClient(apiKey, apiHost string) *Client {
+// Option configures a Client.
+type Option func(*Client)
+
+// WithRetries sets how many times a request is attempted and the initial
+// backoff, which doubles after every failed attempt.
+func WithRetries(maxAttempts int, baseBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.baseBackoff = baseBackoff
+	}
+}
+
+// WithCacheTTL sets how long completed-match scorecards and the recent
+// matches list are cached. A TTL of 0 disables that cache.
+func WithCacheTTL(scorecard, recent time.Duration) Option {
+	return func(c *Client) {
+		c.scorecardTTL = scorecard
+		c.recentTTL = recent
+	}
+}
+
+func NewClient(apiKey, apiHost string, opts ...Option) *Client {
 	if apiHost == "" {
 		apiHost = "crickbuzz-official-apis.p.rapidapi.com"
 	}
-	return &Client{
-		apiKey:  apiKey,
-		apiHost: apiHost,
-		http:    &http.Client{Timeout: 15 * time.Second},
+	c := &Client{
+		apiKey:       apiKey,
+		apiHost:      apiHost,
+		http:         &http.Client{Timeout: 15 * time.Second},
+		maxAttempts:  3,
+		baseBackoff:  500 * time.Millisecond,
+		maxBackoff:   10 * time.Second,
+		scorecardTTL: 24 * time.Hour,
+		recentTTL:    2 * time.Minute,
+		cache:        newTTLCache(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-func (c *Client) doRequest(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// Name returns the provider name of the client.
+func (c *Client) Name() string {
+	return ProviderName
+}
+
+// Quota returns the last known RapidAPI quota and this client's counters.
+func (c *Client) Quota() Quota {
+	return c.quota.snapshot()
+}
+
+// doRequest performs a GET with retries. 429 and 5xx responses and transport
+// errors are retried with exponential backoff, honouring Retry-After.
+func (c *Client) doRequest(ctx context.Context, url string) ([]byte, error) {
+	if c.quota.exhausted() {
+		return nil, ErrQuotaExhausted
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.maxAttempts; attempt++ {
+		if attempt > 0 {
+			c.quota.count(0, 1, 0)
+		}
+
+		body, retryAfter, err := c.attempt(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if retryAfter < 0 || attempt == c.maxAttempts-1 {
+			break
+		}
+
+		wait := c.backoff(attempt)
+		if retryAfter > 0 {
+			wait = min(retryAfter, c.maxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for retry: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+	return nil, lastErr
+}
+
+// attempt makes a single request. retryAfter is negative when the error is
+// not worth retrying, zero to use the default backoff, or the server's
+// Retry-After delay.
+func (c *Client) attempt(ctx context.Context, url string) (body []byte, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, -1, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("x-rapidapi-key", c.apiKey)
 	req.Header.Set("x-rapidapi-host", c.apiHost)
 
+	c.quota.count(1, 0, 0)
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		if ctx.Err() != nil {
+			return nil, -1, fmt.Errorf("execute request: %w", err)
+		}
+		return nil, 0, fmt.Errorf("execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	c.quota.observe(resp.Header)
+
+	body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read body: %w", err)
+		return nil, 0, fmt.Errorf("read body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+		err = fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body[:min(len(body), 200)]))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return nil, parseRetryAfter(resp.Header.Get("Retry-After")), err
+		}
+		return nil, -1, err
 	}
 
-	return body, nil
+	return body, 0, nil
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.baseBackoff << attempt
+	if wait <= 0 || wait > c.maxBackoff {
+		return c.maxBackoff
+	}
+	return wait
 }
 
-// FetchRecentMatches returns recent matches from Cricbuzz.
-func (c *Client) FetchRecentMatches() (*RecentMatchesResponse, error) {
+// parseRetryAfter reads a Retry-After header given in seconds.
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// FetchRecentMatches returns recent matches from Cricbuzz. The list is cached
+// for a short time so repeated page loads don't each cost a request.
+func (c *Client) FetchRecentMatches(ctx context.Context) (*RecentMatchesResponse, error) {
 	url := fmt.Sprintf("https://%s/matches/recent", c.apiHost)
-	body, err := c.doRequest(url)
-	if err != nil {
-		return nil, err
+	body, ok := c.cache.get(url)
+	if ok {
+		c.quota.count(0, 0, 1)
+	} else {
+		var err error
+		if body, err = c.doRequest(ctx, url); err != nil {
+			return nil, err
+		}
 	}
 
 	var result RecentMatchesResponse
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("decode recent matches: %w", err)
 	}
+	if !ok {
+		c.cache.set(url, body, c.recentTTL)
+	}
 	return &result, nil
 }
 
-// FetchScorecard returns the full scorecard for a given match ID.
-func (c *Client) FetchScorecard(matchID int) (*ScorecardResponse, error) {
+// FetchScorecard returns the full scorecard for a given match ID. Scorecards
+// of completed matches don't change, so they are cached.
+func (c *Client) FetchScorecard(ctx context.Context, matchID int) (*ScorecardResponse, error) {
 	url := fmt.Sprintf("https://%s/match/%d/scorecard", c.apiHost, matchID)
-	body, err := c.doRequest(url)
-	if err != nil {
-		return nil, err
+	body, ok := c.cache.get(url)
+	if ok {
+		c.quota.count(0, 0, 1)
+	} else {
+		var err error
+		if body, err = c.doRequest(ctx, url); err != nil {
+			return nil, err
+		}
 	}
 
 	var result ScorecardResponse
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("decode scorecard: %w", err)
 	}
+	if !ok && result.IsMatchComplete {
+		c.cache.set(url, body, c.scorecardTTL)
+	}
+	return &result, nil
+}
+
+// FetchSeriesMatches returns the schedule of a series, played and upcoming.
+// It changes rarely, so it is cached like the recent matches list.
+func (c *Client) FetchSeriesMatches(ctx context.Context, seriesID int) (*SeriesMatchesResponse, error) {
+	url := fmt.Sprintf("https://%s/series/%d/matches", c.apiHost, seriesID)
+	body, ok := c.cache.get(url)
+	if ok {
+		c.quota.count(0, 0, 1)
+	} else {
+		var err error
+		if body, err = c.doRequest(ctx, url); err != nil {
+			return nil, err
+		}
+	}
+
+	var result SeriesMatchesResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("decode series matches: %w", err)
+	}
+	if !ok {
+		c.cache.set(url, body, c.recentTTL)
+	}
 	return &result, nil
 }
 
@@ -98,32 +260,85 @@ func FilterIPLMatches(resp *RecentMatchesResponse) []SimplifiedMatch {
 			if !strings.Contains(strings.ToLower(sm.SeriesAdWrapper.SeriesName), "indian premier league") {
 				continue
 			}
-			for _, m := range sm.SeriesAdWrapper.Matches {
-				if m.MatchInfo.State != "Complete" {
-					continue
-				}
-				simplified := SimplifiedMatch{
-					MatchID:    m.MatchInfo.MatchID,
-					MatchDesc:  m.MatchInfo.MatchDesc,
-					Team1SName: m.MatchInfo.Team1.TeamSName,
-					Team2SName: m.MatchInfo.Team2.TeamSName,
-					Team1Name:  m.MatchInfo.Team1.TeamName,
-					Team2Name:  m.MatchInfo.Team2.TeamName,
-					Status:     m.MatchInfo.Status,
-				}
-				if m.MatchScore != nil {
-					if m.MatchScore.Team1Score != nil && m.MatchScore.Team1Score.Inngs1 != nil {
-						s := m.MatchScore.Team1Score.Inngs1
-						simplified.Team1Score = fmt.Sprintf("%d/%d (%.1f)", s.Runs, s.Wickets, s.Overs)
-					}
-					if m.MatchScore.Team2Score != nil && m.MatchScore.Team2Score.Inngs1 != nil {
-						s := m.MatchScore.Team2Score.Inngs1
-						simplified.Team2Score = fmt.Sprintf("%d/%d (%.1f)", s.Runs, s.Wickets, s.Overs)
-					}
-				}
-				matches = append(matches, simplified)
+			matches = append(matches, completedMatches(sm.SeriesAdWrapper)...)
+		}
+	}
+
+	return matches
+}
+
+// FilterSeriesMatches extracts completed matches of a series from the recent
+// matches response, whatever its match type (league, international,
+// women's or domestic).
+func FilterSeriesMatches(resp *RecentMatchesResponse, seriesID int) []SimplifiedMatch {
+	var matches []SimplifiedMatch
+
+	for _, tm := range resp.TypeMatches {
+		for _, sm := range tm.SeriesMatches {
+			if sm.SeriesAdWrapper == nil || sm.SeriesAdWrapper.SeriesID != seriesID {
+				continue
+			}
+			matches = append(matches, completedMatches(sm.SeriesAdWrapper)...)
+		}
+	}
+
+	return matches
+}
+
+// ListSeries returns the series in the recent matches response, to find the
+// ID of a series to link an auction to.
+func ListSeries(resp *RecentMatchesResponse) []Series {
+	var series []Series
+	seen := make(map[int]bool)
+
+	for _, tm := range resp.TypeMatches {
+		for _, sm := range tm.SeriesMatches {
+			if sm.SeriesAdWrapper == nil || seen[sm.SeriesAdWrapper.SeriesID] {
+				continue
+			}
+			seen[sm.SeriesAdWrapper.SeriesID] = true
+			series = append(series, Series{
+				SeriesID:   sm.SeriesAdWrapper.SeriesID,
+				SeriesName: sm.SeriesAdWrapper.SeriesName,
+				MatchType:  tm.MatchType,
+			})
+		}
+	}
+
+	return series
+}
+
+// completedMatches simplifies the completed matches of a series.
+func completedMatches(series *SeriesAdWrapper) []SimplifiedMatch {
+	var matches []SimplifiedMatch
+
+	for _, m := range series.Matches {
+		if m.MatchInfo.State != "Complete" {
+			continue
+		}
+		simplified := SimplifiedMatch{
+			MatchID:     m.MatchInfo.MatchID,
+			SeriesID:    series.SeriesID,
+			SeriesName:  series.SeriesName,
+			MatchDesc:   m.MatchInfo.MatchDesc,
+			MatchFormat: m.MatchInfo.MatchFormat,
+			Team1SName:  m.MatchInfo.Team1.TeamSName,
+			Team2SName:  m.MatchInfo.Team2.TeamSName,
+			Team1Name:   m.MatchInfo.Team1.TeamName,
+			Team2Name:   m.MatchInfo.Team2.TeamName,
+			Status:      m.MatchInfo.Status,
+		}
+		if m.MatchScore != nil {
+			if m.MatchScore.Team1Score != nil && m.MatchScore.Team1Score.Inngs1 != nil {
+				s := m.MatchScore.Team1Score.Inngs1
+				simplified.Team1Score = fmt.Sprintf("%d/%d (%.1f)", s.Runs, s.Wickets, s.Overs)
+			}
+			if m.MatchScore.Team2Score != nil && m.MatchScore.Team2Score.Inngs1 != nil {
+				s := m.MatchScore.Team2Score.Inngs1
+				simplified.Team2Score = fmt.Sprintf("%d/%d (%.1f)", s.Runs, s.Wickets, s.Overs)
 			}
 		}
+		matches = append(matches, simplified)
 	}
 
 	return matches
@@ -0,0 +1,79 @@
+package cricbuzz
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExhausted is returned without calling the API when the last
+// response reported no remaining requests and the quota has not reset yet.
+var ErrQuotaExhausted = errors.New("cricbuzz API quota exhausted")
+
+// Quota is the RapidAPI request quota as last reported by the API, plus
+// counters for this process.
+type Quota struct {
+	Known     bool      `json:"known"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	Requests  int       `json:"requests"`
+	Retries   int       `json:"retries"`
+	CacheHits int       `json:"cache_hits"`
+}
+
+type quotaTracker struct {
+	mu    sync.Mutex
+	quota Quota
+}
+
+// observe records the rate-limit headers of a RapidAPI response.
+func (t *quotaTracker) observe(h http.Header) {
+	limit, limitErr := strconv.Atoi(h.Get("X-RateLimit-Requests-Limit"))
+	remaining, remainingErr := strconv.Atoi(h.Get("X-RateLimit-Requests-Remaining"))
+	if limitErr != nil && remainingErr != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.quota.Known = true
+	t.quota.UpdatedAt = now
+	if limitErr == nil {
+		t.quota.Limit = limit
+	}
+	if remainingErr == nil {
+		t.quota.Remaining = remaining
+	}
+	// The reset header is the number of seconds until the quota resets.
+	if reset, err := strconv.Atoi(h.Get("X-RateLimit-Requests-Reset")); err == nil {
+		t.quota.ResetAt = now.Add(time.Duration(reset) * time.Second)
+	}
+}
+
+// exhausted reports whether the known quota is used up and not yet reset.
+func (t *quotaTracker) exhausted() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	q := t.quota
+	return q.Known && q.Remaining <= 0 && !q.ResetAt.IsZero() && time.Now().Before(q.ResetAt)
+}
+
+func (t *quotaTracker) count(requests, retries, cacheHits int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quota.Requests += requests
+	t.quota.Retries += retries
+	t.quota.CacheHits += cacheHits
+}
+
+func (t *quotaTracker) snapshot() Quota {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.quota
+}
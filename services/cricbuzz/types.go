@@ -27,17 +27,24 @@ type MatchItem struct {
 }
 
 type MatchDetail struct {
-	MatchID         int      `json:"matchId"`
-	SeriesID        int      `json:"seriesId"`
-	SeriesName      string   `json:"seriesName"`
-	MatchDesc       string   `json:"matchDesc"`
-	MatchFormat     string   `json:"matchFormat"`
-	State           string   `json:"state"`
-	Status          string   `json:"status"`
-	Team1           TeamInfo `json:"team1"`
-	Team2           TeamInfo `json:"team2"`
-	StateTitle      string   `json:"stateTitle"`
-	IsTimeAnnounced bool     `json:"isTimeAnnounced"`
+	MatchID         int        `json:"matchId"`
+	SeriesID        int        `json:"seriesId"`
+	SeriesName      string     `json:"seriesName"`
+	MatchDesc       string     `json:"matchDesc"`
+	MatchFormat     string     `json:"matchFormat"`
+	State           string     `json:"state"`
+	Status          string     `json:"status"`
+	Team1           TeamInfo   `json:"team1"`
+	Team2           TeamInfo   `json:"team2"`
+	StateTitle      string     `json:"stateTitle"`
+	IsTimeAnnounced bool       `json:"isTimeAnnounced"`
+	StartDate       string     `json:"startDate,omitempty"` // Unix milliseconds
+	VenueInfo       *VenueInfo `json:"venueInfo,omitempty"`
+}
+
+type VenueInfo struct {
+	Ground string `json:"ground"`
+	City   string `json:"city"`
 }
 
 type TeamInfo struct {
@@ -62,12 +69,30 @@ type InningsScore struct {
 	Overs     float64 `json:"overs"`
 }
 
+// ── Series Matches Response ──────────────────────────────────────────────────
+
+type SeriesMatchesResponse struct {
+	MatchDetails []SeriesMatchDetails `json:"matchDetails"`
+}
+
+// SeriesMatchDetails groups the matches of one day; entries without a map
+// are advertisements.
+type SeriesMatchDetails struct {
+	MatchDetailsMap *MatchDetailsMap `json:"matchDetailsMap"`
+}
+
+type MatchDetailsMap struct {
+	Key   string      `json:"key"`
+	Match []MatchItem `json:"match"`
+}
+
 // ── Scorecard Response ───────────────────────────────────────────────────────
 
 type ScorecardResponse struct {
 	Scorecard       []ScorecardInnings `json:"scorecard"`
 	IsMatchComplete bool               `json:"ismatchcomplete"`
 	Status          string             `json:"status"`
+	MatchHeader     *MatchDetail       `json:"matchHeader,omitempty"`
 }
 
 type ScorecardInnings struct {
@@ -113,13 +138,22 @@ type Bowler struct {
 // ── Simplified Match (returned by our API) ───────────────────────────────────
 
 type SimplifiedMatch struct {
-	MatchID    int    `json:"match_id"`
-	MatchDesc  string `json:"match_desc"`
-	Team1SName string `json:"team1_sname"`
-	Team2SName string `json:"team2_sname"`
-	Team1Name  string `json:"team1_name"`
-	Team2Name  string `json:"team2_name"`
-	Status     string `json:"status"`
-	Team1Score string `json:"team1_score"`
-	Team2Score string `json:"team2_score"`
+	MatchID     int    `json:"match_id"`
+	SeriesID    int    `json:"series_id"`
+	SeriesName  string `json:"series_name"`
+	MatchDesc   string `json:"match_desc"`
+	MatchFormat string `json:"match_format"`
+	Team1SName  string `json:"team1_sname"`
+	Team2SName  string `json:"team2_sname"`
+	Team1Name   string `json:"team1_name"`
+	Team2Name   string `json:"team2_name"`
+	Status      string `json:"status"`
+	Team1Score  string `json:"team1_score"`
+	Team2Score  string `json:"team2_score"`
+}
+
+type Series struct {
+	SeriesID   int    `json:"series_id"`
+	SeriesName string `json:"series_name"`
+	MatchType  string `json:"match_type"`
 }
@@ -3,199 +3,187 @@ package fantasy
 import (
 	"cric-auction-monolith/services/cricbuzz"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-// DismissalType represents how a batsman got out.
-type DismissalType int
-
+// Dot ball scoring methods reported in PointBreakdown.DotBalls.
 const (
-	NotOut DismissalType = iota
-	Caught
-	Bowled
-	LBW
-	Stumped
-	RunOut
-	CaughtAndBowled
-	RetiredHurt
-	HitWicket
-	Other
+	DotBallsActual = "actual" // dots taken from the scorecard
+	DotBallsProxy  = "proxy"  // dots estimated from economy
 )
 
-// DismissalInfo holds parsed information from an outdec string.
-type DismissalInfo struct {
-	Type          DismissalType
-	Fielder       string // catcher or stumper
-	Bowler        string
-	RunOutThrower string // first name in run out (direct hit)
-	RunOutAssist  string // second name in run out (not direct hit)
-	IsDirectHit   bool
-	RawText       string
-}
-
 // PointBreakdown shows how fantasy points were calculated.
 type PointBreakdown struct {
-	Batting  int      `json:"batting"`
-	Bowling  int      `json:"bowling"`
-	Fielding int      `json:"fielding"`
-	Bonus    int      `json:"bonus"`
-	Details  []string `json:"details"`
+	Batting  int `json:"batting"`
+	Bowling  int `json:"bowling"`
+	Fielding int `json:"fielding"`
+	Bonus    int `json:"bonus"`
+	Duck     int `json:"duck,omitempty"` // duck penalty as calculated
+	// DuckWaived is the duck penalty taken back out of Batting because the
+	// player is a bowler; see ForRole.
+	DuckWaived int    `json:"duck_waived,omitempty"`
+	DotBalls   string `json:"dot_balls,omitempty"`
+	// Void is set when the points were not counted (a void no-result or
+	// impact rules) and PlayerPoints.Points is 0 whatever the breakdown.
+	Void    bool     `json:"void,omitempty"`
+	Details []string `json:"details"`
 }
 
 // PlayerPoints is the result for a single player.
 type PlayerPoints struct {
+	CricbuzzID   int            `json:"cricbuzz_id,omitempty"`
 	CricbuzzName string         `json:"cricbuzz_name"`
+	Impact       string         `json:"impact,omitempty"`
 	Points       int            `json:"points"`
 	Breakdown    PointBreakdown `json:"breakdown"`
 }
 
-var (
-	reCaught       = regexp.MustCompile(`^c (.+?) b (.+)$`)
-	reCaughtBowled = regexp.MustCompile(`^c and b (.+)$`)
-	reStumped      = regexp.MustCompile(`^st (.+?) b (.+)$`)
-	reRunOut2      = regexp.MustCompile(`^run out \((.+?)/(.+?)\)$`)
-	reRunOut1      = regexp.MustCompile(`^run out \((.+?)\)$`)
-	reLBW          = regexp.MustCompile(`^lbw b (.+)$`)
-	reBowled       = regexp.MustCompile(`^b (.+)$`)
-	reHitWicket    = regexp.MustCompile(`^hit wicket b (.+)$`)
-)
+// CalculateAllPoints computes fantasy points for every player in a scorecard
+// using the default rules.
+func CalculateAllPoints(scorecard *cricbuzz.ScorecardResponse) map[string]*PlayerPoints {
+	return CalculateAllPointsWithRules(scorecard, DefaultRules())
+}
 
-// ParseDismissal extracts fielding and bowling info from an outdec string.
-func ParseDismissal(outDec string) DismissalInfo {
-	outDec = strings.TrimSpace(outDec)
-	info := DismissalInfo{RawText: outDec}
-
-	if outDec == "" || outDec == "not out" || strings.HasPrefix(outDec, "retired") {
-		info.Type = NotOut
-		if strings.HasPrefix(outDec, "retired") {
-			info.Type = RetiredHurt
-		}
-		return info
-	}
-
-	if m := reHitWicket.FindStringSubmatch(outDec); m != nil {
-		info.Type = HitWicket
-		info.Bowler = strings.TrimSpace(m[1])
-		return info
-	}
-	if m := reCaughtBowled.FindStringSubmatch(outDec); m != nil {
-		info.Type = CaughtAndBowled
-		info.Fielder = strings.TrimSpace(m[1])
-		info.Bowler = strings.TrimSpace(m[1])
-		return info
-	}
-	if m := reCaught.FindStringSubmatch(outDec); m != nil {
-		info.Type = Caught
-		info.Fielder = strings.TrimSpace(m[1])
-		info.Bowler = strings.TrimSpace(m[2])
-		return info
-	}
-	if m := reStumped.FindStringSubmatch(outDec); m != nil {
-		info.Type = Stumped
-		info.Fielder = strings.TrimSpace(m[1])
-		info.Bowler = strings.TrimSpace(m[2])
-		return info
-	}
-	if m := reRunOut2.FindStringSubmatch(outDec); m != nil {
-		info.Type = RunOut
-		info.RunOutThrower = strings.TrimSpace(m[1])
-		info.RunOutAssist = strings.TrimSpace(m[2])
-		info.IsDirectHit = false
-		return info
-	}
-	if m := reRunOut1.FindStringSubmatch(outDec); m != nil {
-		info.Type = RunOut
-		info.RunOutThrower = strings.TrimSpace(m[1])
-		info.IsDirectHit = true
-		return info
-	}
-	if m := reLBW.FindStringSubmatch(outDec); m != nil {
-		info.Type = LBW
-		info.Bowler = strings.TrimSpace(m[1])
-		return info
-	}
-	if m := reBowled.FindStringSubmatch(outDec); m != nil {
-		info.Type = Bowled
-		info.Bowler = strings.TrimSpace(m[1])
-		return info
-	}
-
-	info.Type = Other
-	return info
+// CalculateAllPointsWithRules computes fantasy points for every player in a
+// scorecard using the given rules.
+func CalculateAllPointsWithRules(scorecard *cricbuzz.ScorecardResponse, rules Rules) map[string]*PlayerPoints {
+	results, _ := CalculateMatchPoints(scorecard, rules)
+	return results
 }
 
-// CalculateAllPoints computes fantasy points for every player in a scorecard.
-func CalculateAllPoints(scorecard *cricbuzz.ScorecardResponse) map[string]*PlayerPoints {
+// CalculateMatchPoints computes fantasy points for every player in a
+// scorecard using the given rules, and reports how the match was detected.
+// The format's preset sets the thresholds and bonuses, every innings a
+// player bats or bowls in is scored, and super-over innings, reduced-overs
+// thresholds and abandoned or no-result matches are scored by the rules'
+// policies.
+func CalculateMatchPoints(scorecard *cricbuzz.ScorecardResponse, rules Rules) (map[string]*PlayerPoints, MatchInfo) {
 	results := make(map[string]*PlayerPoints)
+	preset := rules.preset(scorecard)
+	info := DetectMatch(scorecard, rules)
+	noResult := info.Kind == MatchNoResult
+
+	// scored reports whether the innings at index i earns points.
+	scored := func(i int) bool {
+		return !info.Innings[i].SuperOver || rules.SuperOver == SuperOverCount
+	}
 
 	// Build a name alias map: maps all known name variants to a canonical name.
 	// This fixes issues like "Philip Salt" (batsman name) vs "Phil Salt" (in outdec).
 	aliases := buildNameAliases(scorecard)
 
+	// Impact substitutes, by lowercase canonical name.
+	impact := make(map[string]string)
+	for _, inn := range scorecard.Scorecard {
+		for _, bat := range inn.Batsmen {
+			if state := parseImpact(bat.InMatchChange); state != "" {
+				impact[strings.ToLower(resolveAlias(aliases, bat.Name))] = state
+			}
+		}
+		for _, bowl := range inn.Bowlers {
+			if state := parseImpact(bowl.InMatchChange); state != "" {
+				impact[strings.ToLower(resolveAlias(aliases, bowl.Name))] = state
+			}
+		}
+	}
+
+	// +4 for playing in the match, once per player, subject to impact rules.
+	appeared := make(map[*PlayerPoints]bool)
+	awardAppearance := func(pp *PlayerPoints) {
+		if appeared[pp] {
+			return
+		}
+		appeared[pp] = true
+		if noResult && rules.NoResult != NoResultScore {
+			pp.Breakdown.Details = append(pp.Breakdown.Details, "No result: no playing bonus")
+			return
+		}
+		if rule := rules.impactRule(pp.Impact); rule != nil && !rule.AppearanceBonus {
+			pp.Breakdown.Details = append(pp.Breakdown.Details, fmt.Sprintf("Impact player %s: no playing bonus", pp.Impact))
+			return
+		}
+		pp.Breakdown.Bonus += 4
+		pp.Breakdown.Details = append(pp.Breakdown.Details, "Playing in match (+4)")
+	}
+
 	// Collect all dismissals from both innings (for fielding points).
 	var allDismissals []DismissalInfo
-	for _, inn := range scorecard.Scorecard {
+	for i, inn := range scorecard.Scorecard {
+		if !scored(i) {
+			continue
+		}
 		for _, bat := range inn.Batsmen {
 			d := ParseDismissal(bat.OutDesc)
 			allDismissals = append(allDismissals, d)
 		}
 	}
 
-	// Track which players appeared (for dedup).
+	// Track which players batted in each innings (for dedup).
 	type playerKey struct {
-		name string
-		team string
+		name    string
+		team    string
+		innings int
 	}
 	seen := make(map[playerKey]bool)
 
 	// Process each innings.
-	for _, inn := range scorecard.Scorecard {
+	for i, inn := range scorecard.Scorecard {
+		if !scored(i) {
+			continue
+		}
 		teamSName := inn.BatTeamSName
+		thresholds := info.Innings[i]
 
 		// ── Batting points ──
 		for _, bat := range inn.Batsmen {
 			canonical := resolveAlias(aliases, bat.Name)
-			key := playerKey{name: strings.ToLower(canonical), team: teamSName}
+			key := playerKey{name: strings.ToLower(canonical), team: teamSName, innings: i}
 			if seen[key] {
 				continue
 			}
 			seen[key] = true
 
 			pp := getOrCreate(results, canonical)
-			batting, details := calcBatting(bat)
+			if pp.CricbuzzID == 0 {
+				pp.CricbuzzID = bat.ID
+			}
+			pp.Impact = impact[strings.ToLower(canonical)]
+			batting, duck, details := calcBatting(bat, preset, thresholds.StrikeRateMin)
 			pp.Breakdown.Batting += batting
+			pp.Breakdown.Duck += duck
 			pp.Breakdown.Details = append(pp.Breakdown.Details, details...)
 
-			// +4 for playing in the match (every player who appeared).
-			if pp.Breakdown.Bonus == 0 {
-				pp.Breakdown.Bonus += 4
-				pp.Breakdown.Details = append(pp.Breakdown.Details, "Playing in match (+4)")
-			}
+			awardAppearance(pp)
 		}
 
 		// ── Bowling points ──
 		for _, bowl := range inn.Bowlers {
 			canonical := resolveAlias(aliases, bowl.Name)
 			pp := getOrCreate(results, canonical)
-			bowling, details := calcBowling(bowl, inn.Batsmen)
+			if pp.CricbuzzID == 0 {
+				pp.CricbuzzID = bowl.ID
+			}
+			pp.Impact = impact[strings.ToLower(canonical)]
+			bowling, details, dotMethod := calcBowling(bowl, inn.Batsmen, preset, thresholds.EconomyMin)
 			pp.Breakdown.Bowling += bowling
 			pp.Breakdown.Details = append(pp.Breakdown.Details, details...)
-
-			// +4 for playing (if not already given from batting).
-			if pp.Breakdown.Bonus == 0 {
-				pp.Breakdown.Bonus += 4
-				pp.Breakdown.Details = append(pp.Breakdown.Details, "Playing in match (+4)")
+			if dotMethod != "" {
+				pp.Breakdown.DotBalls = dotMethod
 			}
+
+			awardAppearance(pp)
 		}
 	}
 
 	// ── Fielding points (across entire match) ──
-	fieldingStats := calcFieldingStats(allDismissals)
+	fieldingStats := calcFieldingStats(allDismissals, rules)
 	for name, stats := range fieldingStats {
 		canonical := resolveAlias(aliases, name)
 		pp := getOrCreate(results, canonical)
+		if pp.Impact == "" {
+			pp.Impact = impact[strings.ToLower(canonical)]
+		}
 		pp.Breakdown.Fielding += stats.points
 		pp.Breakdown.Details = append(pp.Breakdown.Details, stats.details...)
 	}
@@ -203,9 +191,20 @@ func CalculateAllPoints(scorecard *cricbuzz.ScorecardResponse) map[string]*Playe
 	// Sum totals.
 	for _, pp := range results {
 		pp.Points = pp.Breakdown.Batting + pp.Breakdown.Bowling + pp.Breakdown.Fielding + pp.Breakdown.Bonus
+		if noResult && rules.NoResult == NoResultVoid {
+			pp.Breakdown.Details = append(pp.Breakdown.Details, fmt.Sprintf("No result: points void (%+d)", -pp.Points))
+			pp.Points = 0
+			pp.Breakdown.Void = true
+			continue
+		}
+		if rule := rules.impactRule(pp.Impact); rule != nil && !rule.CountPoints {
+			pp.Breakdown.Details = append(pp.Breakdown.Details, fmt.Sprintf("Impact player %s: points not counted (%+d)", pp.Impact, -pp.Points))
+			pp.Points = 0
+			pp.Breakdown.Void = true
+		}
 	}
 
-	return results
+	return results, info
 }
 
 // buildNameAliases creates a mapping from all name variants to a canonical name.
@@ -299,7 +298,9 @@ func getOrCreate(m map[string]*PlayerPoints, name string) *PlayerPoints {
 
 // ── Batting ──────────────────────────────────────────────────────────────────
 
-func calcBatting(bat cricbuzz.Batsman) (int, []string) {
+// calcBatting scores one innings of a batsman and returns the points, the
+// duck penalty included in them and the details.
+func calcBatting(bat cricbuzz.Batsman, preset Preset, minBalls int) (int, int, []string) {
 	points := 0
 	var details []string
 
@@ -324,40 +325,33 @@ func calcBatting(bat cricbuzz.Batsman) (int, []string) {
 	}
 
 	// Milestone bonus (highest applicable only).
-	switch {
-	case bat.Runs >= 100:
-		points += 16
-		details = append(details, "Century bonus (+16)")
-	case bat.Runs >= 75:
-		points += 12
-		details = append(details, "75-run bonus (+12)")
-	case bat.Runs >= 50:
-		points += 8
-		details = append(details, "Half-century bonus (+8)")
-	case bat.Runs >= 25:
-		points += 4
-		details = append(details, "25-run bonus (+4)")
+	if m, ok := highestMilestone(preset.runMilestones, bat.Runs); ok {
+		points += m.points
+		details = append(details, fmt.Sprintf("%s (+%d)", m.label, m.points))
 	}
 
 	// Duck.
-	isOut := bat.OutDesc != "" && bat.OutDesc != "not out" && !strings.HasPrefix(bat.OutDesc, "retired")
-	if bat.Runs == 0 && isOut && bat.Balls > 0 {
-		// Duck applies to BAT, WK, AR (controller will filter by DB role).
-		points -= 2
-		details = append(details, "Duck (-2)")
-	}
-
-	// Strike rate bonus/penalty (min 10 balls).
-	if bat.Balls >= 10 {
+	duck := 0
+	isOut := ParseDismissal(bat.OutDesc).Type.IsOut()
+	if bat.Runs == 0 && isOut && bat.Balls > 0 && preset.Duck != 0 {
+		// Duck applies to BAT, WK, AR; ForRole waives it for bowlers.
+		duck = preset.Duck
+		points += duck
+		details = append(details, fmt.Sprintf("Duck (%d)", duck))
+	}
+
+	// Strike rate bonus/penalty (min balls by format, fewer in reduced
+	// matches).
+	if preset.strikeRate != nil && minBalls > 0 && bat.Balls >= minBalls {
 		sr := (float64(bat.Runs) / float64(bat.Balls)) * 100
-		srBonus := calcStrikeRateBonus(sr)
+		srBonus := preset.strikeRate(sr)
 		if srBonus != 0 {
 			points += srBonus
 			details = append(details, fmt.Sprintf("SR %.1f (%+d)", sr, srBonus))
 		}
 	}
 
-	return points, details
+	return points, duck, details
 }
 
 func calcStrikeRateBonus(sr float64) int {
@@ -382,7 +376,7 @@ func calcStrikeRateBonus(sr float64) int {
 
 // ── Bowling ──────────────────────────────────────────────────────────────────
 
-func calcBowling(bowl cricbuzz.Bowler, batsmen []cricbuzz.Batsman) (int, []string) {
+func calcBowling(bowl cricbuzz.Bowler, batsmen []cricbuzz.Batsman, preset Preset, minBalls int) (int, []string, string) {
 	points := 0
 	var details []string
 
@@ -397,8 +391,8 @@ func calcBowling(bowl cricbuzz.Bowler, batsmen []cricbuzz.Batsman) (int, []strin
 		if !namesMatch(d.Bowler, bowl.Name) {
 			continue
 		}
-		// Run outs don't count as bowler wickets.
-		if d.Type == RunOut {
+		// Run outs, obstruction and the like don't count as bowler wickets.
+		if !d.Type.CreditsBowler() {
 			continue
 		}
 		wickets++
@@ -422,55 +416,65 @@ func calcBowling(bowl cricbuzz.Bowler, batsmen []cricbuzz.Batsman) (int, []strin
 	}
 
 	// Wicket milestone bonus (highest applicable only).
-	switch {
-	case wickets >= 5:
-		points += 12
-		details = append(details, "5-wicket bonus (+12)")
-	case wickets >= 4:
-		points += 8
-		details = append(details, "4-wicket bonus (+8)")
-	case wickets >= 3:
-		points += 4
-		details = append(details, "3-wicket bonus (+4)")
+	if m, ok := highestMilestone(preset.wicketMilestones, wickets); ok {
+		points += m.points
+		details = append(details, fmt.Sprintf("%s (+%d)", m.label, m.points))
 	}
 
 	// Maiden overs.
-	if bowl.Maidens > 0 {
-		bonus := bowl.Maidens * 12
+	if bowl.Maidens > 0 && preset.Maiden > 0 {
+		bonus := bowl.Maidens * preset.Maiden
 		points += bonus
 		details = append(details, fmt.Sprintf("%d maiden(s) (+%d)", bowl.Maidens, bonus))
 	}
 
-	// Economy rate bonus/penalty (min 2 overs).
+	// Dot balls: points per dot when the scorecard has them.
+	dotMethod := ""
+	if bowl.Dots > 0 && preset.DotBall > 0 {
+		dotMethod = DotBallsActual
+		bonus := bowl.Dots * preset.DotBall
+		points += bonus
+		details = append(details, fmt.Sprintf("%d dot balls (+%d)", bowl.Dots, bonus))
+	}
+
+	// Economy rate bonus/penalty (min balls by format, fewer in reduced
+	// matches).
 	overs := parseOvers(bowl.Overs)
-	if overs >= 2.0 {
+	if preset.economy != nil && minBalls > 0 && oversToBalls(overs) >= minBalls {
 		eco := parseFloat(bowl.Economy)
-		ecoBonus := calcEconomyBonus(eco)
+		ecoBonus := preset.economy(eco)
+		label := fmt.Sprintf("Economy %.1f", eco)
+
+		// No dot data for a bowler who bowled: estimate dots from economy.
+		if dotMethod == "" && preset.dotProxy != nil && (bowl.Balls > 0 || overs > 0) {
+			dotMethod = DotBallsProxy
+			proxy := preset.dotProxy(eco)
+			ecoBonus += proxy
+			label += fmt.Sprintf(" incl. dot proxy %+d", proxy)
+		}
+
 		if ecoBonus != 0 {
 			points += ecoBonus
-			details = append(details, fmt.Sprintf("Economy %.1f (%+d)", eco, ecoBonus))
+			details = append(details, fmt.Sprintf("%s (%+d)", label, ecoBonus))
 		}
 	}
 
-	return points, details
+	return points, details, dotMethod
 }
 
-
-// calcEconomyBonus returns economy rate bonus/penalty WITH dot ball compensation.
-// Since Cricbuzz API doesn't provide dot ball data, the economy brackets
-// include a dot-ball proxy: lower economy → more dots → higher bonus.
+// calcEconomyBonus returns the economy rate bonus/penalty on its own.
 func calcEconomyBonus(eco float64) int {
 	switch {
 	case eco < 5:
-		return 22 // +6 ER + 16 Dot Proxy
+		return 6
 	case eco < 6:
-		return 16 // +4 ER + 12 Dot Proxy
+		return 4
 	case eco <= 7:
-		return 10 // +2 ER + 10 Dot Proxy
+		return 2
 	case eco > 7 && eco < 10:
-		return 7 // 0 ER + 7 Dot Proxy
+		return 0
 	case eco <= 11:
-		return 0 // -2 ER + 2 Dot Proxy
+		return -2
 	case eco <= 12:
 		return -4
 	case eco > 12:
@@ -479,6 +483,27 @@ func calcEconomyBonus(eco float64) int {
 	return 0
 }
 
+// calcDotBallProxy estimates dot ball points from economy for scorecards
+// without dot ball data: lower economy → more dots → higher bonus. Added to
+// calcEconomyBonus it gives the combined economy brackets used before dot
+// balls were scored (22, 16, 10, 7, 0, -4, -6), so totals for scorecards
+// without dots are unchanged.
+func calcDotBallProxy(eco float64) int {
+	switch {
+	case eco < 5:
+		return 16
+	case eco < 6:
+		return 12
+	case eco <= 7:
+		return 8
+	case eco > 7 && eco < 10:
+		return 7
+	case eco <= 11:
+		return 2
+	}
+	return 0
+}
+
 // ── Fielding ─────────────────────────────────────────────────────────────────
 
 type fieldingResult struct {
@@ -486,7 +511,7 @@ type fieldingResult struct {
 	details []string
 }
 
-func calcFieldingStats(dismissals []DismissalInfo) map[string]*fieldingResult {
+func calcFieldingStats(dismissals []DismissalInfo, rules Rules) map[string]*fieldingResult {
 	stats := make(map[string]*fieldingResult)
 
 	getResult := func(name string) *fieldingResult {
@@ -504,6 +529,18 @@ func calcFieldingStats(dismissals []DismissalInfo) map[string]*fieldingResult {
 	catchCount := make(map[string]int) // lowercase name -> count
 
 	for _, d := range dismissals {
+		if rules.SubstituteFielding == SubstituteFieldingSkip {
+			if d.IsSubstitute(d.Fielder) {
+				d.Fielder = ""
+			}
+			if d.IsSubstitute(d.RunOutThrower) {
+				d.RunOutThrower = ""
+			}
+			if d.IsSubstitute(d.RunOutAssist) {
+				d.RunOutAssist = ""
+			}
+		}
+
 		switch d.Type {
 		case Caught, CaughtAndBowled:
 			if d.Fielder != "" {
@@ -519,21 +556,24 @@ func calcFieldingStats(dismissals []DismissalInfo) map[string]*fieldingResult {
 				r.details = append(r.details, "Stumping (+12)")
 			}
 		case RunOut:
-			if d.RunOutThrower != "" {
-				if d.IsDirectHit {
+			if d.IsDirectHit {
+				if d.RunOutThrower != "" {
 					r := getResult(d.RunOutThrower)
 					r.points += 12
 					r.details = append(r.details, "Run out direct hit (+12)")
-				} else {
-					r := getResult(d.RunOutThrower)
-					r.points += 6
-					r.details = append(r.details, "Run out throw (+6)")
-					if d.RunOutAssist != "" {
-						r2 := getResult(d.RunOutAssist)
-						r2.points += 6
-						r2.details = append(r2.details, "Run out assist (+6)")
-					}
 				}
+				continue
+			}
+			if d.RunOutThrower != "" {
+				r := getResult(d.RunOutThrower)
+				r.points += 6
+				r.details = append(r.details, "Run out throw (+6)")
+			}
+			// The assist is credited even when a substitute thrower is skipped.
+			if d.RunOutAssist != "" {
+				r2 := getResult(d.RunOutAssist)
+				r2.points += 6
+				r2.details = append(r2.details, "Run out assist (+6)")
 			}
 		}
 	}
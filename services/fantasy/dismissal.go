@@ -0,0 +1,253 @@
+package fantasy
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DismissalType represents how a batsman got out.
+type DismissalType int
+
+const (
+	NotOut DismissalType = iota
+	Caught
+	Bowled
+	LBW
+	Stumped
+	RunOut
+	CaughtAndBowled
+	RetiredHurt // retired hurt, ill or not out: the batsman is not out
+	HitWicket
+	RetiredOut
+	ObstructingField
+	HandledBall
+	TimedOut
+	HitBallTwice
+	AbsentHurt // did not bat because of injury or illness: not out
+	Other
+)
+
+var dismissalNames = map[DismissalType]string{
+	NotOut:           "not_out",
+	Caught:           "caught",
+	Bowled:           "bowled",
+	LBW:              "lbw",
+	Stumped:          "stumped",
+	RunOut:           "run_out",
+	CaughtAndBowled:  "caught_and_bowled",
+	RetiredHurt:      "retired_hurt",
+	HitWicket:        "hit_wicket",
+	RetiredOut:       "retired_out",
+	ObstructingField: "obstructing_field",
+	HandledBall:      "handled_ball",
+	TimedOut:         "timed_out",
+	HitBallTwice:     "hit_ball_twice",
+	AbsentHurt:       "absent_hurt",
+	Other:            "other",
+}
+
+// String returns the name of the type, e.g. "caught_and_bowled".
+func (t DismissalType) String() string {
+	if name, ok := dismissalNames[t]; ok {
+		return name
+	}
+	return dismissalNames[Other]
+}
+
+// MarshalText encodes the type by its name.
+func (t DismissalType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// CreditsBowler reports whether the bowler is credited with the wicket.
+// Run outs, obstruction, handling the ball, hitting it twice, timed out and
+// retired out are not the bowler's wicket.
+func (t DismissalType) CreditsBowler() bool {
+	switch t {
+	case Caught, CaughtAndBowled, Bowled, LBW, Stumped, HitWicket:
+		return true
+	}
+	return false
+}
+
+// IsOut reports whether the batsman was dismissed. Unrecognised dismissal
+// text counts as out.
+func (t DismissalType) IsOut() bool {
+	switch t {
+	case NotOut, RetiredHurt, AbsentHurt:
+		return false
+	}
+	return true
+}
+
+// DismissalInfo holds parsed information from an outdec string.
+type DismissalInfo struct {
+	Type          DismissalType `json:"type"`
+	Fielder       string        `json:"fielder,omitempty"`         // catcher or stumper
+	Bowler        string        `json:"bowler,omitempty"`          // only set when the bowler is credited
+	RunOutThrower string        `json:"run_out_thrower,omitempty"` // first name in run out (direct hit)
+	RunOutAssist  string        `json:"run_out_assist,omitempty"`  // second name in run out (not direct hit)
+	IsDirectHit   bool          `json:"is_direct_hit,omitempty"`
+	Substitutes   []string      `json:"substitutes,omitempty"` // fielders marked as substitutes, e.g. "c sub (X) b Y"
+	Keepers       []string      `json:"keepers,omitempty"`     // fielders marked as wicket-keeper, e.g. "c †X b Y"
+	RawText       string        `json:"raw_text"`
+}
+
+var (
+	reCaught       = regexp.MustCompile(`^c (.+?) b (.+)$`)
+	reCaughtBowled = regexp.MustCompile(`^c (?:and|&) b (.+)$`)
+	reStumped      = regexp.MustCompile(`^st (.+?) b (.+)$`)
+	reRunOut2      = regexp.MustCompile(`^run out \((.+?)/(.+?)\)$`)
+	reRunOut1      = regexp.MustCompile(`^run out \((.+?)\)$`)
+	reLBW          = regexp.MustCompile(`^lbw b (.+)$`)
+	reBowled       = regexp.MustCompile(`^b (.+)$`)
+	reHitWicket    = regexp.MustCompile(`^hit (?:wicket|wkt) b (.+)$`)
+	reSubstitute   = regexp.MustCompile(`^sub \(?([^()]+?)\)?$`)
+	reKeeper       = regexp.MustCompile(`^†\s*|\s*\(wk\)$`)
+	reRetired      = regexp.MustCompile(`^ret(?:ired|d)\.?(?: (.+))?$`)
+	reSpaces       = regexp.MustCompile(`\s+`)
+)
+
+// Dismissals without a fielder or bowler, by the start of their text.
+var fixedDismissals = []struct {
+	prefixes []string
+	typ      DismissalType
+}{
+	{[]string{"obstructing the field", "obstructing field", "obstructed the field", "obs the field", "obs field"}, ObstructingField},
+	{[]string{"handled the ball", "handled ball"}, HandledBall},
+	{[]string{"timed out"}, TimedOut},
+	{[]string{"hit the ball twice", "hit ball twice"}, HitBallTwice},
+	{[]string{"absent"}, AbsentHurt},
+}
+
+// substituteName strips a "sub (X)" marker and reports whether it was there.
+func substituteName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if m := reSubstitute.FindStringSubmatch(name); m != nil {
+		return strings.TrimSpace(m[1]), true
+	}
+	return name, false
+}
+
+// keeperName strips a "†X" or "X (wk)" marker and reports whether it was
+// there.
+func keeperName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	stripped := reKeeper.ReplaceAllString(name, "")
+	return strings.TrimSpace(stripped), stripped != name
+}
+
+// fielder records a fielder name on the dismissal, unwrapping substitute and
+// wicket-keeper markers.
+func (d *DismissalInfo) fielder(name string) string {
+	name, isKeeper := keeperName(name)
+	name, isSub := substituteName(name)
+	if isSub {
+		d.Substitutes = append(d.Substitutes, name)
+	}
+	if isKeeper {
+		d.Keepers = append(d.Keepers, name)
+	}
+	return name
+}
+
+// IsSubstitute reports whether the named fielder was a substitute.
+func (d DismissalInfo) IsSubstitute(name string) bool {
+	return containsFold(d.Substitutes, name)
+}
+
+// IsKeeper reports whether the named fielder was marked as wicket-keeper.
+func (d DismissalInfo) IsKeeper(name string) bool {
+	return containsFold(d.Keepers, name)
+}
+
+func containsFold(names []string, name string) bool {
+	for _, s := range names {
+		if strings.EqualFold(s, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDismissal extracts fielding and bowling info from an outdec string.
+func ParseDismissal(outDec string) DismissalInfo {
+	outDec = strings.TrimSpace(reSpaces.ReplaceAllString(outDec, " "))
+	info := DismissalInfo{RawText: outDec}
+	lower := strings.ToLower(outDec)
+
+	if lower == "" || lower == "not out" || lower == "batting" {
+		info.Type = NotOut
+		return info
+	}
+	if m := reRetired.FindStringSubmatch(lower); m != nil {
+		info.Type = RetiredHurt
+		if m[1] == "out" {
+			info.Type = RetiredOut
+		}
+		return info
+	}
+	for _, f := range fixedDismissals {
+		for _, prefix := range f.prefixes {
+			if strings.HasPrefix(lower, prefix) {
+				info.Type = f.typ
+				return info
+			}
+		}
+	}
+
+	if m := reHitWicket.FindStringSubmatch(outDec); m != nil {
+		info.Type = HitWicket
+		info.Bowler = strings.TrimSpace(m[1])
+		return info
+	}
+	if m := reCaughtBowled.FindStringSubmatch(outDec); m != nil {
+		info.Type = CaughtAndBowled
+		info.Fielder = strings.TrimSpace(m[1])
+		info.Bowler = strings.TrimSpace(m[1])
+		return info
+	}
+	if m := reCaught.FindStringSubmatch(outDec); m != nil {
+		info.Type = Caught
+		info.Fielder = info.fielder(m[1])
+		info.Bowler = strings.TrimSpace(m[2])
+		return info
+	}
+	if m := reStumped.FindStringSubmatch(outDec); m != nil {
+		info.Type = Stumped
+		info.Fielder = info.fielder(m[1])
+		info.Bowler = strings.TrimSpace(m[2])
+		return info
+	}
+	if m := reRunOut2.FindStringSubmatch(outDec); m != nil {
+		info.Type = RunOut
+		info.RunOutThrower = info.fielder(m[1])
+		info.RunOutAssist = info.fielder(m[2])
+		info.IsDirectHit = false
+		return info
+	}
+	if m := reRunOut1.FindStringSubmatch(outDec); m != nil {
+		info.Type = RunOut
+		info.RunOutThrower = info.fielder(m[1])
+		info.IsDirectHit = true
+		return info
+	}
+	if lower == "run out" {
+		// Fielder not recorded.
+		info.Type = RunOut
+		return info
+	}
+	if m := reLBW.FindStringSubmatch(outDec); m != nil {
+		info.Type = LBW
+		info.Bowler = strings.TrimSpace(m[1])
+		return info
+	}
+	if m := reBowled.FindStringSubmatch(outDec); m != nil {
+		info.Type = Bowled
+		info.Bowler = strings.TrimSpace(m[1])
+		return info
+	}
+
+	info.Type = Other
+	return info
+}
@@ -0,0 +1,113 @@
+package fantasy
+
+import (
+	"reflect"
+	"testing"
+)
+
+// dismissalCorpus holds dismissal strings taken from real scorecards. It is
+// the golden set for ParseDismissal: every change to the grammar must keep
+// it passing.
+var dismissalCorpus = []struct {
+	text string
+	want DismissalInfo
+}{
+	// Not out.
+	{"not out", DismissalInfo{Type: NotOut}},
+	{"batting", DismissalInfo{Type: NotOut}},
+	{"", DismissalInfo{Type: NotOut}},
+	{"retired hurt", DismissalInfo{Type: RetiredHurt}},
+	{"retd hurt", DismissalInfo{Type: RetiredHurt}},
+	{"retired not out", DismissalInfo{Type: RetiredHurt}},
+	{"retired ill", DismissalInfo{Type: RetiredHurt}},
+	{"absent hurt", DismissalInfo{Type: AbsentHurt}},
+	{"absent ill", DismissalInfo{Type: AbsentHurt}},
+
+	// Caught.
+	{"c Ruturaj Gaikwad b Ravindra Jadeja", DismissalInfo{Type: Caught, Fielder: "Ruturaj Gaikwad", Bowler: "Ravindra Jadeja"}},
+	{"c Faf du Plessis b Mohammed Siraj", DismissalInfo{Type: Caught, Fielder: "Faf du Plessis", Bowler: "Mohammed Siraj"}},
+	{"c AB de Villiers b Yuzvendra Chahal", DismissalInfo{Type: Caught, Fielder: "AB de Villiers", Bowler: "Yuzvendra Chahal"}},
+	{"c †MS Dhoni b Deepak Chahar", DismissalInfo{Type: Caught, Fielder: "MS Dhoni", Bowler: "Deepak Chahar", Keepers: []string{"MS Dhoni"}}},
+	{"c Rishabh Pant (wk) b Axar Patel", DismissalInfo{Type: Caught, Fielder: "Rishabh Pant", Bowler: "Axar Patel", Keepers: []string{"Rishabh Pant"}}},
+	{"c sub (Rinku Singh) b Varun Chakaravarthy", DismissalInfo{Type: Caught, Fielder: "Rinku Singh", Bowler: "Varun Chakaravarthy", Substitutes: []string{"Rinku Singh"}}},
+	{"c sub Abhinav Manohar b Rashid Khan", DismissalInfo{Type: Caught, Fielder: "Abhinav Manohar", Bowler: "Rashid Khan", Substitutes: []string{"Abhinav Manohar"}}},
+	{"c  Shubman Gill  b Mohammed Shami", DismissalInfo{Type: Caught, Fielder: "Shubman Gill", Bowler: "Mohammed Shami"}},
+
+	// Caught and bowled.
+	{"c and b Sunil Narine", DismissalInfo{Type: CaughtAndBowled, Fielder: "Sunil Narine", Bowler: "Sunil Narine"}},
+	{"c & b Kuldeep Yadav", DismissalInfo{Type: CaughtAndBowled, Fielder: "Kuldeep Yadav", Bowler: "Kuldeep Yadav"}},
+
+	// Bowled, lbw, hit wicket.
+	{"b Jasprit Bumrah", DismissalInfo{Type: Bowled, Bowler: "Jasprit Bumrah"}},
+	{"lbw b Trent Boult", DismissalInfo{Type: LBW, Bowler: "Trent Boult"}},
+	{"hit wicket b Mohammed Shami", DismissalInfo{Type: HitWicket, Bowler: "Mohammed Shami"}},
+	{"hit wkt b Bhuvneshwar Kumar", DismissalInfo{Type: HitWicket, Bowler: "Bhuvneshwar Kumar"}},
+
+	// Stumped.
+	{"st Sanju Samson b Ravichandran Ashwin", DismissalInfo{Type: Stumped, Fielder: "Sanju Samson", Bowler: "Ravichandran Ashwin"}},
+	{"st †MS Dhoni b Ravindra Jadeja", DismissalInfo{Type: Stumped, Fielder: "MS Dhoni", Bowler: "Ravindra Jadeja", Keepers: []string{"MS Dhoni"}}},
+
+	// Run out.
+	{"run out (Ravindra Jadeja)", DismissalInfo{Type: RunOut, RunOutThrower: "Ravindra Jadeja", IsDirectHit: true}},
+	{"run out (Ravindra Jadeja/†MS Dhoni)", DismissalInfo{Type: RunOut, RunOutThrower: "Ravindra Jadeja", RunOutAssist: "MS Dhoni", Keepers: []string{"MS Dhoni"}}},
+	{"run out (David Miller/Rahul Tewatia)", DismissalInfo{Type: RunOut, RunOutThrower: "David Miller", RunOutAssist: "Rahul Tewatia"}},
+	{"run out (sub (Jitesh Sharma))", DismissalInfo{Type: RunOut, RunOutThrower: "Jitesh Sharma", IsDirectHit: true, Substitutes: []string{"Jitesh Sharma"}}},
+	{"run out (sub (Shahbaz Ahmed)/Dinesh Karthik)", DismissalInfo{Type: RunOut, RunOutThrower: "Shahbaz Ahmed", RunOutAssist: "Dinesh Karthik", Substitutes: []string{"Shahbaz Ahmed"}}},
+	{"run out", DismissalInfo{Type: RunOut}},
+
+	// No bowler credit.
+	{"retired out", DismissalInfo{Type: RetiredOut}},
+	{"retd out", DismissalInfo{Type: RetiredOut}},
+	{"obstructing the field", DismissalInfo{Type: ObstructingField}},
+	{"obs the field", DismissalInfo{Type: ObstructingField}},
+	{"handled the ball", DismissalInfo{Type: HandledBall}},
+	{"timed out", DismissalInfo{Type: TimedOut}},
+	{"hit the ball twice", DismissalInfo{Type: HitBallTwice}},
+
+	// Unrecognised text.
+	{"dismissed", DismissalInfo{Type: Other}},
+}
+
+func TestParseDismissalCorpus(t *testing.T) {
+	for _, c := range dismissalCorpus {
+		got := ParseDismissal(c.text)
+		want := c.want
+		want.RawText = got.RawText
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseDismissal(%q) = %+v, want %+v", c.text, got, want)
+		}
+	}
+}
+
+func TestDismissalTypeCredits(t *testing.T) {
+	tests := []struct {
+		typ           DismissalType
+		creditsBowler bool
+		isOut         bool
+	}{
+		{NotOut, false, false},
+		{Caught, true, true},
+		{CaughtAndBowled, true, true},
+		{Bowled, true, true},
+		{LBW, true, true},
+		{Stumped, true, true},
+		{HitWicket, true, true},
+		{RunOut, false, true},
+		{RetiredHurt, false, false},
+		{AbsentHurt, false, false},
+		{RetiredOut, false, true},
+		{ObstructingField, false, true},
+		{HandledBall, false, true},
+		{TimedOut, false, true},
+		{HitBallTwice, false, true},
+		{Other, false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.CreditsBowler(); got != tt.creditsBowler {
+			t.Errorf("%s.CreditsBowler() = %v, want %v", tt.typ, got, tt.creditsBowler)
+		}
+		if got := tt.typ.IsOut(); got != tt.isOut {
+			t.Errorf("%s.IsOut() = %v, want %v", tt.typ, got, tt.isOut)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package fantasy
+
+import (
+	"cric-auction-monolith/services/cricbuzz"
+	"strings"
+)
+
+// Match formats with a scoring preset.
+const (
+	FormatT20  = "T20"
+	FormatODI  = "ODI"
+	FormatTest = "TEST"
+)
+
+// Preset is the format-specific part of the points calculation. Runs,
+// boundaries, wickets, fielding and the playing bonus score the same in
+// every format.
+type Preset struct {
+	Format string `json:"format"`
+	// ScheduledOvers is the overs per innings; 0 when unlimited.
+	ScheduledOvers int `json:"scheduled_overs"`
+	InningsPerTeam int `json:"innings_per_team"`
+	// Minimum balls faced or bowled for the strike-rate and economy
+	// bonuses; 0 when the format has no such bonus.
+	StrikeRateMinBalls int `json:"strike_rate_min_balls"`
+	EconomyMinBalls    int `json:"economy_min_balls"`
+	Duck               int `json:"duck"`
+	Maiden             int `json:"maiden"`
+	DotBall            int `json:"dot_ball"`
+
+	runMilestones    []milestone // highest first, only the highest reached counts
+	wicketMilestones []milestone
+	strikeRate       func(sr float64) int
+	economy          func(eco float64) int
+	dotProxy         func(eco float64) int // nil: no estimate without dot data
+}
+
+type milestone struct {
+	at     int
+	points int
+	label  string
+}
+
+var (
+	t20RunMilestones = []milestone{
+		{100, 16, "Century bonus"},
+		{75, 12, "75-run bonus"},
+		{50, 8, "Half-century bonus"},
+		{25, 4, "25-run bonus"},
+	}
+	longRunMilestones = append([]milestone{
+		{150, 24, "150-run bonus"},
+		{125, 20, "125-run bonus"},
+	}, t20RunMilestones...)
+)
+
+var presets = map[string]Preset{
+	FormatT20: {
+		Format:             FormatT20,
+		ScheduledOvers:     20,
+		InningsPerTeam:     1,
+		StrikeRateMinBalls: 10,
+		EconomyMinBalls:    12,
+		Duck:               -2,
+		Maiden:             12,
+		DotBall:            1,
+		runMilestones:      t20RunMilestones,
+		wicketMilestones: []milestone{
+			{5, 12, "5-wicket bonus"},
+			{4, 8, "4-wicket bonus"},
+			{3, 4, "3-wicket bonus"},
+		},
+		strikeRate: calcStrikeRateBonus,
+		economy:    calcEconomyBonus,
+		dotProxy:   calcDotBallProxy,
+	},
+	FormatODI: {
+		Format:             FormatODI,
+		ScheduledOvers:     50,
+		InningsPerTeam:     1,
+		StrikeRateMinBalls: 20,
+		EconomyMinBalls:    30,
+		Duck:               -3,
+		Maiden:             4,
+		runMilestones:      longRunMilestones,
+		wicketMilestones: []milestone{
+			{6, 12, "6-wicket bonus"},
+			{5, 8, "5-wicket bonus"},
+			{4, 4, "4-wicket bonus"},
+		},
+		strikeRate: calcODIStrikeRateBonus,
+		economy:    calcODIEconomyBonus,
+	},
+	FormatTest: {
+		Format:         FormatTest,
+		InningsPerTeam: 2,
+		Duck:           -4,
+		runMilestones:  longRunMilestones,
+		wicketMilestones: []milestone{
+			{5, 8, "5-wicket bonus"},
+			{4, 4, "4-wicket bonus"},
+		},
+	},
+}
+
+// NormalizeFormat maps a Cricbuzz match format ("T20I", "ODI", "TEST"...)
+// to a preset format, or "" when there is no preset for it.
+func NormalizeFormat(format string) string {
+	switch strings.ToUpper(strings.TrimSpace(format)) {
+	case "T20", "T20I":
+		return FormatT20
+	case "ODI", "LIST A":
+		return FormatODI
+	case "TEST", "FC", "FIRST CLASS":
+		return FormatTest
+	}
+	return ""
+}
+
+// PresetFor returns the preset of a match format, falling back to T20.
+func PresetFor(format string) Preset {
+	if p, ok := presets[NormalizeFormat(format)]; ok {
+		return p
+	}
+	return presets[FormatT20]
+}
+
+// preset returns the preset a scorecard is scored with: the rules' format
+// when set, otherwise the format in the scorecard's match header. A
+// configured ScheduledOvers overrides the format's.
+func (r Rules) preset(scorecard *cricbuzz.ScorecardResponse) Preset {
+	format := r.Format
+	if format == "" && scorecard.MatchHeader != nil {
+		format = scorecard.MatchHeader.MatchFormat
+	}
+	p := PresetFor(format)
+	if r.ScheduledOvers > 0 && p.ScheduledOvers > 0 {
+		p.ScheduledOvers = r.ScheduledOvers
+	}
+	return p
+}
+
+// highestMilestone returns the highest milestone reached, if any.
+func highestMilestone(milestones []milestone, n int) (milestone, bool) {
+	for _, m := range milestones {
+		if n >= m.at {
+			return m, true
+		}
+	}
+	return milestone{}, false
+}
+
+func calcODIStrikeRateBonus(sr float64) int {
+	switch {
+	case sr > 140:
+		return 6
+	case sr > 120:
+		return 4
+	case sr >= 100:
+		return 2
+	case sr >= 50:
+		return 0
+	case sr >= 40:
+		return -2
+	case sr >= 30:
+		return -4
+	}
+	return -6
+}
+
+func calcODIEconomyBonus(eco float64) int {
+	switch {
+	case eco < 2.5:
+		return 6
+	case eco < 3.5:
+		return 4
+	case eco <= 4.5:
+		return 2
+	case eco < 7:
+		return 0
+	case eco <= 8:
+		return -2
+	case eco <= 9:
+		return -4
+	}
+	return -6
+}
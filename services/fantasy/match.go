@@ -0,0 +1,138 @@
+package fantasy
+
+import (
+	"cric-auction-monolith/services/cricbuzz"
+	"math"
+	"strings"
+)
+
+// Match kinds reported by DetectMatch.
+const (
+	MatchNormal   = "normal"
+	MatchReduced  = "reduced"   // at least one innings had its overs cut
+	MatchNoResult = "no_result" // abandoned or no result
+)
+
+// MatchInfo describes how a match was played, as far as scoring cares.
+type MatchInfo struct {
+	Kind           string        `json:"kind"`
+	Format         string        `json:"format"`
+	ScheduledOvers int           `json:"scheduled_overs"`
+	SuperOvers     int           `json:"super_overs"`
+	Innings        []InningsInfo `json:"innings"`
+}
+
+// InningsInfo is one innings of a scorecard, in scorecard order, with the
+// thresholds it is scored with.
+type InningsInfo struct {
+	InningsID int    `json:"innings_id"`
+	Team      string `json:"team"`
+	SuperOver bool   `json:"super_over"`
+	// Overs is how many overs the innings was allowed; below the scheduled
+	// overs when it was cut short, 0 when unlimited.
+	Overs         float64 `json:"overs"`
+	Reduced       bool    `json:"reduced"`
+	StrikeRateMin int     `json:"strike_rate_min_balls"`
+	EconomyMin    int     `json:"economy_min_balls"`
+}
+
+// DetectMatch works out from the scorecard and its status whether a match
+// was abandoned, shortened or went to super-overs, and the strike-rate and
+// economy minimums each innings is scored with under the rules.
+//
+// In limited-overs formats, innings after the main ones that last at most
+// an over are super-overs. An innings is reduced when it ended short of the scheduled overs without
+// the side being bowled out and the innings was closed rather than won: the
+// first innings once the chase has started, the chase only when the result
+// was decided on a revised target (DLS, VJD or a reduced match).
+func DetectMatch(scorecard *cricbuzz.ScorecardResponse, rules Rules) MatchInfo {
+	preset := rules.preset(scorecard)
+	scheduled := preset.ScheduledOvers
+	info := MatchInfo{Kind: MatchNormal, Format: preset.Format, ScheduledOvers: scheduled, Innings: []InningsInfo{}}
+
+	status := strings.ToLower(scorecard.Status)
+	revised := strings.Contains(status, "dls") || strings.Contains(status, "d/l") ||
+		strings.Contains(status, "vjd") || strings.Contains(status, "reduced")
+	if strings.Contains(status, "abandon") || strings.Contains(status, "no result") {
+		info.Kind = MatchNoResult
+	}
+
+	innings := scorecard.Scorecard
+
+	// The innings a revised target applies to: the last main innings.
+	main := 0
+	for i, inn := range innings {
+		if scheduled > 0 && i >= 2*preset.InningsPerTeam && inn.Overs <= 1 {
+			break
+		}
+		main++
+	}
+
+	for i, inn := range innings {
+		ii := InningsInfo{
+			InningsID:     inn.InningsID,
+			Team:          inn.BatTeamSName,
+			Overs:         float64(scheduled),
+			StrikeRateMin: preset.StrikeRateMinBalls,
+			EconomyMin:    preset.EconomyMinBalls,
+		}
+		if i >= main {
+			ii.SuperOver = true
+			ii.Overs = 1
+			info.SuperOvers++
+			info.Innings = append(info.Innings, ii)
+			continue
+		}
+
+		limited := scheduled > 0 && preset.InningsPerTeam == 1
+		short := inn.Wickets < 10 && oversToBalls(inn.Overs) < scheduled*6
+		if limited && short && info.Kind != MatchNoResult && (i == 0 && main == 2 || revised && i == main-1) {
+			ii.Reduced = true
+			ii.Overs = inn.Overs
+			if info.Kind == MatchNormal {
+				info.Kind = MatchReduced
+			}
+			if rules.ReducedOvers == ReducedOversScale {
+				ratio := float64(oversToBalls(inn.Overs)) / float64(scheduled*6)
+				ii.StrikeRateMin = scaleMin(preset.StrikeRateMinBalls, ratio)
+				ii.EconomyMin = scaleMin(preset.EconomyMinBalls, ratio)
+			}
+		}
+		info.Innings = append(info.Innings, ii)
+	}
+
+	// Both sides bat to the same revised length: a first innings cut short
+	// before the chase started shortens the chase too.
+	if main == 2 && info.Innings[0].Reduced && !info.Innings[1].Reduced {
+		first := info.Innings[0]
+		second := &info.Innings[1]
+		second.Reduced = true
+		second.Overs = first.Overs
+		second.StrikeRateMin = first.StrikeRateMin
+		second.EconomyMin = first.EconomyMin
+	}
+
+	return info
+}
+
+// scaleMin scales a full-length minimum by ratio, rounding up, and never
+// below one ball. A minimum of 0, meaning no bonus, stays 0.
+func scaleMin(min int, ratio float64) int {
+	if min == 0 {
+		return 0
+	}
+	n := int(math.Ceil(float64(min) * ratio))
+	if n < 1 {
+		return 1
+	}
+	if n > min {
+		return min
+	}
+	return n
+}
+
+// oversToBalls converts overs in the 12.4 notation to balls.
+func oversToBalls(overs float64) int {
+	whole := math.Floor(overs)
+	return int(whole)*6 + int(math.Round((overs-whole)*10))
+}
@@ -10,17 +10,51 @@ type DBPlayer struct {
 	IPLTeam    string
 	Role       string
 	MatchID    string // MongoDB ObjectID as hex string
+	CricbuzzID int    // stored Cricbuzz player ID (0 if never mapped)
 }
 
 // MatchResult holds the result of matching a Cricbuzz player to a DB player.
 type MatchResult struct {
 	DBIndex    int     // index into the DB players slice (-1 if no match)
 	Confidence float64 // 1.0 = exact, 0.8 = last-name, 0.6 = substring
+	ByID       bool    // true when matched through the stored Cricbuzz ID
+}
+
+// MatchPlayer matches a Cricbuzz player to a DB player, preferring the stored
+// Cricbuzz ID. Name matching is only used as a fallback and only considers
+// DB players that have never been mapped to a Cricbuzz ID. teams tells which
+// scorecard team names belong to the same franchise.
+func MatchPlayer(cricbuzzID int, cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPlayer, teams TeamAliases) MatchResult {
+	if cricbuzzID > 0 {
+		for i, p := range dbPlayers {
+			if p.CricbuzzID == cricbuzzID {
+				return MatchResult{DBIndex: i, Confidence: 1.0, ByID: true}
+			}
+		}
+	}
+
+	// Only unmapped players take part in name matching, so a player who is
+	// already bound to another Cricbuzz ID can never be picked up by surname.
+	unmapped := make([]DBPlayer, 0, len(dbPlayers))
+	indexes := make([]int, 0, len(dbPlayers))
+	for i, p := range dbPlayers {
+		if p.CricbuzzID != 0 {
+			continue
+		}
+		unmapped = append(unmapped, p)
+		indexes = append(indexes, i)
+	}
+
+	match := MatchPlayerToDB(cricbuzzName, cricbuzzTeam, unmapped, teams)
+	if match.DBIndex >= 0 {
+		match.DBIndex = indexes[match.DBIndex]
+	}
+	return match
 }
 
 // MatchPlayerToDB matches a Cricbuzz player name to the best DB player.
 // cricbuzzTeam is the short team name from the scorecard innings (e.g., "CSK").
-func MatchPlayerToDB(cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPlayer) MatchResult {
+func MatchPlayerToDB(cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPlayer, teams TeamAliases) MatchResult {
 	cbLower := strings.ToLower(strings.TrimSpace(cricbuzzName))
 
 	// Pass 1: Exact match (case-insensitive).
@@ -34,7 +68,7 @@ func MatchPlayerToDB(cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPla
 	cbLast := lastWord(cbLower)
 	if len(cbLast) > 2 {
 		for i, p := range dbPlayers {
-			if !teamMatches(cricbuzzTeam, p.IPLTeam) {
+			if !teams.Match(cricbuzzTeam, p.IPLTeam) {
 				continue
 			}
 			dbLast := lastWord(strings.ToLower(p.PlayerName))
@@ -46,7 +80,7 @@ func MatchPlayerToDB(cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPla
 
 	// Pass 3: Substring containment within same IPL team.
 	for i, p := range dbPlayers {
-		if !teamMatches(cricbuzzTeam, p.IPLTeam) {
+		if !teams.Match(cricbuzzTeam, p.IPLTeam) {
 			continue
 		}
 		pLower := strings.ToLower(strings.TrimSpace(p.PlayerName))
@@ -58,42 +92,37 @@ func MatchPlayerToDB(cricbuzzName string, cricbuzzTeam string, dbPlayers []DBPla
 	return MatchResult{DBIndex: -1, Confidence: 0}
 }
 
-// teamMatches checks if two team identifiers refer to the same team.
+// TeamAliases maps a team's short name to the other names it goes by, e.g.
+// "CSK" to "Chennai Super Kings" and "Chennai", all in upper case.
+type TeamAliases map[string][]string
+
+// Match checks if two team identifiers refer to the same team.
 // Handles both short names ("CSK") and full names ("Chennai Super Kings").
-func teamMatches(a, b string) bool {
+func (t TeamAliases) Match(a, b string) bool {
 	a = strings.ToUpper(strings.TrimSpace(a))
 	b = strings.ToUpper(strings.TrimSpace(b))
 	if a == b {
 		return true
 	}
+	return t.resolveShort(a) == t.resolveShort(b)
+}
 
-	// Map of short name -> possible full names / variations.
-	teamAliases := map[string][]string{
-		"CSK":  {"CHENNAI SUPER KINGS", "CHENNAI"},
-		"MI":   {"MUMBAI INDIANS", "MUMBAI"},
-		"RCB":  {"ROYAL CHALLENGERS BENGALURU", "ROYAL CHALLENGERS BANGALORE", "BENGALURU", "BANGALORE"},
-		"RR":   {"RAJASTHAN ROYALS", "RAJASTHAN"},
-		"KKR":  {"KOLKATA KNIGHT RIDERS", "KOLKATA"},
-		"SRH":  {"SUNRISERS HYDERABAD", "SUNRISERS", "HYDERABAD"},
-		"DC":   {"DELHI CAPITALS", "DELHI"},
-		"PBKS": {"PUNJAB KINGS", "PUNJAB"},
-		"GT":   {"GUJARAT TITANS", "GUJARAT"},
-		"LSG":  {"LUCKNOW SUPER GIANTS", "LUCKNOW"},
-	}
+// Short returns the short name of the franchise a team identifier belongs
+// to, or the identifier upper-cased when no franchise has it.
+func (t TeamAliases) Short(name string) string {
+	return t.resolveShort(strings.ToUpper(strings.TrimSpace(name)))
+}
 
-	resolveShort := func(name string) string {
-		if _, ok := teamAliases[name]; ok {
-			return name
-		}
-		for short, aliases := range teamAliases {
-			for _, alias := range aliases {
-				if name == alias {
-					return short
-				}
+func (t TeamAliases) resolveShort(name string) string {
+	if _, ok := t[name]; ok {
+		return name
+	}
+	for short, aliases := range t {
+		for _, alias := range aliases {
+			if name == alias {
+				return short
 			}
 		}
-		return name
 	}
-
-	return resolveShort(a) == resolveShort(b)
+	return name
 }
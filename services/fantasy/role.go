@@ -0,0 +1,33 @@
+package fantasy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsBowlerRole reports whether an auction role is a specialist bowler.
+// All-rounders are not.
+func IsBowlerRole(role string) bool {
+	role = strings.ToUpper(role)
+	return strings.Contains(role, "BOWL") && !strings.Contains(role, "ALL")
+}
+
+// ForRole adjusts a player's points for their role in the auction:
+// specialist bowlers are not penalised for a duck. The waived penalty is
+// taken out of Batting, recorded in DuckWaived and listed in the details.
+// Void points stay 0.
+func (pp PlayerPoints) ForRole(role string) PlayerPoints {
+	b := pp.Breakdown
+	if b.Duck == 0 || b.DuckWaived != 0 || !IsBowlerRole(role) {
+		return pp
+	}
+
+	b.Batting -= b.Duck
+	b.DuckWaived = -b.Duck
+	b.Details = append(append([]string{}, b.Details...), fmt.Sprintf("Bowler: duck penalty waived (%+d)", b.DuckWaived))
+	if !b.Void {
+		pp.Points -= b.Duck
+	}
+	pp.Breakdown = b
+	return pp
+}
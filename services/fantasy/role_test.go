@@ -0,0 +1,38 @@
+package fantasy
+
+import "testing"
+
+func TestForRole(t *testing.T) {
+	duck := func(points int, void bool) PlayerPoints {
+		return PlayerPoints{
+			Points:    points,
+			Breakdown: PointBreakdown{Batting: -2, Bowling: 30, Bonus: 4, Duck: -2, Void: void},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		pp         PlayerPoints
+		role       string
+		wantPoints int
+		wantWaived int
+	}{
+		{"batter keeps the penalty", duck(32, false), "BAT", 32, 0},
+		{"all-rounder keeps the penalty", duck(32, false), "BOWL ALL", 32, 0},
+		{"bowler waives the penalty", duck(32, false), "BOWL", 34, 2},
+		{"void points stay 0", duck(0, true), "BOWL", 0, 2},
+		{"no duck", PlayerPoints{Points: 34, Breakdown: PointBreakdown{Bowling: 30, Bonus: 4}}, "BOWL", 34, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pp.ForRole(tt.role)
+			if got.Points != tt.wantPoints || got.Breakdown.DuckWaived != tt.wantWaived {
+				t.Errorf("got %d points, %d waived; want %d, %d",
+					got.Points, got.Breakdown.DuckWaived, tt.wantPoints, tt.wantWaived)
+			}
+			if twice := got.ForRole(tt.role); twice.Points != got.Points {
+				t.Errorf("waived twice: %d points, want %d", twice.Points, got.Points)
+			}
+		})
+	}
+}
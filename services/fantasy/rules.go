@@ -0,0 +1,94 @@
+package fantasy
+
+import "strings"
+
+// Substitute fielding rules.
+const (
+	SubstituteFieldingCredit = "credit" // fielding points go to the named substitute
+	SubstituteFieldingSkip   = "skip"   // substitutes earn no fielding points
+)
+
+// Super-over policies.
+const (
+	SuperOverIgnore = "ignore" // super-over innings earn no points
+	SuperOverCount  = "count"  // super-over innings are scored like any other
+)
+
+// Reduced-overs policies.
+const (
+	ReducedOversScale = "scale" // strike-rate and economy minimums shrink with the overs
+	ReducedOversFull  = "full"  // the full-length minimums apply
+)
+
+// Abandoned and no-result match policies.
+const (
+	NoResultScore   = "score"    // the partial scorecard is scored as usual
+	NoResultNoBonus = "no_bonus" // scored without the +4 playing bonus
+	NoResultVoid    = "void"     // nobody earns points
+)
+
+// Impact player states derived from Batsman/Bowler.InMatchChange.
+const (
+	ImpactIn  = "in"
+	ImpactOut = "out"
+)
+
+// Rules holds the configurable parts of the points calculation. The zero
+// value is not meaningful; start from DefaultRules and override fields.
+type Rules struct {
+	ImpactPlayerIn     ImpactRule `bson:"impact_player_in" json:"impact_player_in"`
+	ImpactPlayerOut    ImpactRule `bson:"impact_player_out" json:"impact_player_out"`
+	SubstituteFielding string     `bson:"substitute_fielding" json:"substitute_fielding"`
+	SuperOver          string     `bson:"super_over" json:"super_over"`
+	ReducedOvers       string     `bson:"reduced_overs" json:"reduced_overs"`
+	NoResult           string     `bson:"no_result" json:"no_result"`
+	// Format forces the scoring preset; empty reads it from the scorecard.
+	Format string `bson:"format" json:"format"`
+	// ScheduledOvers overrides the format's overs per innings, e.g. for
+	// domestic tournaments with shorter matches; 0 keeps the format's.
+	ScheduledOvers int `bson:"scheduled_overs" json:"scheduled_overs"`
+}
+
+// ImpactRule controls how a player coming in or going out as an impact
+// substitute is scored.
+type ImpactRule struct {
+	AppearanceBonus bool `bson:"appearance_bonus" json:"appearance_bonus"` // award the +4 playing bonus
+	CountPoints     bool `bson:"count_points" json:"count_points"`         // keep the player's match points
+}
+
+// DefaultRules returns the rules used when an auction has not configured any.
+func DefaultRules() Rules {
+	return Rules{
+		ImpactPlayerIn:     ImpactRule{AppearanceBonus: true, CountPoints: true},
+		ImpactPlayerOut:    ImpactRule{AppearanceBonus: true, CountPoints: true},
+		SubstituteFielding: SubstituteFieldingCredit,
+		SuperOver:          SuperOverIgnore,
+		ReducedOvers:       ReducedOversScale,
+		NoResult:           NoResultNoBonus,
+	}
+}
+
+// impactRule returns the rule for an impact state, or nil for regular players.
+func (r Rules) impactRule(state string) *ImpactRule {
+	switch state {
+	case ImpactIn:
+		return &r.ImpactPlayerIn
+	case ImpactOut:
+		return &r.ImpactPlayerOut
+	}
+	return nil
+}
+
+// parseImpact normalises an inmatchchange value ("IN", "OUT", "Impact In"...).
+func parseImpact(inMatchChange string) string {
+	s := strings.ToUpper(strings.TrimSpace(inMatchChange))
+	switch {
+	case s == "":
+		return ""
+	case strings.HasSuffix(s, "OUT"):
+		return ImpactOut
+	case strings.HasSuffix(s, "IN"):
+		return ImpactIn
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package fantasy
+
+import (
+	"strings"
+
+	"cric-auction-monolith/services/cricbuzz"
+)
+
+// PlayerStats are a player's figures read off a scorecard. Super-over
+// innings are left out, as in the official records.
+type PlayerStats struct {
+	Innings         int  `bson:"innings" json:"innings"`
+	NotOuts         int  `bson:"not_outs" json:"not_outs"`
+	Runs            int  `bson:"runs" json:"runs"`
+	Balls           int  `bson:"balls" json:"balls"`
+	Fours           int  `bson:"fours" json:"fours"`
+	Sixes           int  `bson:"sixes" json:"sixes"`
+	HighScore       int  `bson:"high_score" json:"high_score"`
+	HighScoreNotOut bool `bson:"high_score_not_out" json:"high_score_not_out"`
+	Fifties         int  `bson:"fifties" json:"fifties"` // 50 to 99
+	Hundreds        int  `bson:"hundreds" json:"hundreds"`
+	BallsBowled     int  `bson:"balls_bowled" json:"balls_bowled"`
+	RunsConceded    int  `bson:"runs_conceded" json:"runs_conceded"`
+	Wickets         int  `bson:"wickets" json:"wickets"`
+	Maidens         int  `bson:"maidens" json:"maidens"`
+	Catches         int  `bson:"catches" json:"catches"`
+	Stumpings       int  `bson:"stumpings" json:"stumpings"`
+}
+
+// Add adds the figures of s to p.
+func (p *PlayerStats) Add(s PlayerStats) {
+	p.Innings += s.Innings
+	p.NotOuts += s.NotOuts
+	p.Runs += s.Runs
+	p.Balls += s.Balls
+	p.Fours += s.Fours
+	p.Sixes += s.Sixes
+	if s.HighScore > p.HighScore || s.HighScore == p.HighScore && s.HighScoreNotOut {
+		p.HighScore, p.HighScoreNotOut = s.HighScore, s.HighScoreNotOut
+	}
+	p.Fifties += s.Fifties
+	p.Hundreds += s.Hundreds
+	p.BallsBowled += s.BallsBowled
+	p.RunsConceded += s.RunsConceded
+	p.Wickets += s.Wickets
+	p.Maidens += s.Maidens
+	p.Catches += s.Catches
+	p.Stumpings += s.Stumpings
+}
+
+// MatchStats returns the figures of every player in a scorecard, keyed by
+// the same names as CalculateMatchPoints.
+func MatchStats(scorecard *cricbuzz.ScorecardResponse, rules Rules) map[string]*PlayerStats {
+	stats := make(map[string]*PlayerStats)
+	info := DetectMatch(scorecard, rules)
+	aliases := buildNameAliases(scorecard)
+
+	get := func(name string) *PlayerStats {
+		canonical := resolveAlias(aliases, name)
+		for k, v := range stats {
+			if strings.EqualFold(k, canonical) {
+				return v
+			}
+		}
+		s := &PlayerStats{}
+		stats[canonical] = s
+		return s
+	}
+
+	for i, inn := range scorecard.Scorecard {
+		if info.Innings[i].SuperOver {
+			continue
+		}
+		for _, bat := range inn.Batsmen {
+			d := ParseDismissal(bat.OutDesc)
+			out := d.Type.IsOut()
+			s := get(bat.Name)
+			// Listed but never faced a ball nor got out: did not bat.
+			if out || bat.Balls > 0 {
+				s.Innings++
+				if !out {
+					s.NotOuts++
+				}
+				s.Add(PlayerStats{HighScore: bat.Runs, HighScoreNotOut: !out})
+			}
+			s.Runs += bat.Runs
+			s.Balls += bat.Balls
+			s.Fours += bat.Fours
+			s.Sixes += bat.Sixes
+			switch {
+			case bat.Runs >= 100:
+				s.Hundreds++
+			case bat.Runs >= 50:
+				s.Fifties++
+			}
+
+			if d.Fielder == "" {
+				continue
+			}
+			switch d.Type {
+			case Caught, CaughtAndBowled:
+				get(d.Fielder).Catches++
+			case Stumped:
+				get(d.Fielder).Stumpings++
+			}
+		}
+		for _, bowl := range inn.Bowlers {
+			s := get(bowl.Name)
+			balls := bowl.Balls
+			if balls == 0 {
+				balls = oversToBalls(parseOvers(bowl.Overs))
+			}
+			s.BallsBowled += balls
+			s.RunsConceded += bowl.Runs
+			s.Wickets += bowl.Wickets
+			s.Maidens += bowl.Maidens
+		}
+	}
+	return stats
+}
+
+// Lookup returns the figures of the named player, matching names without
+// regard to case.
+func Lookup(stats map[string]*PlayerStats, name string) (PlayerStats, bool) {
+	if s, ok := stats[name]; ok {
+		return *s, true
+	}
+	for k, s := range stats {
+		if strings.EqualFold(k, name) {
+			return *s, true
+		}
+	}
+	return PlayerStats{}, false
+}
@@ -0,0 +1,100 @@
+package franchise
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// iplFranchises seed the registry for auctions without a series.
+var iplFranchises = []models.Franchise{
+	{ShortName: "CSK", Name: "Chennai Super Kings", Aliases: []string{"Chennai"}},
+	{ShortName: "MI", Name: "Mumbai Indians", Aliases: []string{"Mumbai"}},
+	{ShortName: "RCB", Name: "Royal Challengers Bengaluru", Aliases: []string{"Royal Challengers Bangalore", "Bengaluru", "Bangalore"}},
+	{ShortName: "RR", Name: "Rajasthan Royals", Aliases: []string{"Rajasthan"}},
+	{ShortName: "KKR", Name: "Kolkata Knight Riders", Aliases: []string{"Kolkata"}},
+	{ShortName: "SRH", Name: "Sunrisers Hyderabad", Aliases: []string{"Sunrisers", "Hyderabad"}},
+	{ShortName: "DC", Name: "Delhi Capitals", Aliases: []string{"Delhi"}},
+	{ShortName: "PBKS", Name: "Punjab Kings", Aliases: []string{"Punjab"}},
+	{ShortName: "GT", Name: "Gujarat Titans", Aliases: []string{"Gujarat"}},
+	{ShortName: "LSG", Name: "Lucknow Super Giants", Aliases: []string{"Lucknow"}},
+}
+
+// SeedIPL stores the IPL franchises under series 0 unless that series
+// already has franchises.
+func SeedIPL(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(constants.FranchiseCollection)
+	n, err := coll.CountDocuments(ctx, bson.M{"series_id": 0})
+	if err != nil || n > 0 {
+		return err
+	}
+
+	now := time.Now()
+	docs := make([]any, 0, len(iplFranchises))
+	for _, f := range iplFranchises {
+		f.CreatedAt, f.UpdatedAt = now, now
+		docs = append(docs, f)
+	}
+	_, err = coll.InsertMany(ctx, docs)
+	return err
+}
+
+// List returns the franchises of a series ordered by short name.
+func List(ctx context.Context, db *mongo.Database, seriesID int) ([]models.Franchise, error) {
+	cursor, err := db.Collection(constants.FranchiseCollection).Find(ctx,
+		bson.M{"series_id": seriesID},
+		options.Find().SetSort(bson.D{{Key: "short_name", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	franchises := []models.Franchise{}
+	if err := cursor.All(ctx, &franchises); err != nil {
+		return nil, err
+	}
+	return franchises, nil
+}
+
+// Aliases builds the team aliases of a series for player matching.
+func Aliases(ctx context.Context, db *mongo.Database, seriesID int) (fantasy.TeamAliases, error) {
+	franchises, err := List(ctx, db, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(fantasy.TeamAliases, len(franchises))
+	for _, f := range franchises {
+		short := strings.ToUpper(strings.TrimSpace(f.ShortName))
+		names := make([]string, 0, len(f.Aliases)+1)
+		if f.Name != "" {
+			names = append(names, strings.ToUpper(strings.TrimSpace(f.Name)))
+		}
+		for _, a := range f.Aliases {
+			names = append(names, strings.ToUpper(strings.TrimSpace(a)))
+		}
+		aliases[short] = names
+	}
+	return aliases, nil
+}
+
+// ForAuction builds the team aliases of the series an auction is linked to.
+func ForAuction(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (fantasy.TeamAliases, error) {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx,
+		bson.M{"_id": auctionID},
+		options.FindOne().SetProjection(bson.M{"series_id": 1}),
+	).Decode(&auction)
+	if err != nil {
+		return nil, err
+	}
+	return Aliases(ctx, db, auction.SeriesID)
+}
@@ -0,0 +1,230 @@
+// Package gameweek moves auctions from one gameweek to the next: it locks
+// playing XI edits at the gameweek deadline, rotates the saved XI into the
+// current XI when the gameweek starts and keeps a snapshot of every closed
+// gameweek so it can be rolled back to.
+package gameweek
+
+import (
+	"context"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NextOpen returns the earliest gameweek of the auction that has not started
+// yet, or nil when none is scheduled.
+func NextOpen(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (*models.Gameweek, error) {
+	var gw models.Gameweek
+	err := db.Collection(constants.GameweekCollection).FindOne(ctx,
+		bson.M{"auction_id": auctionID, "rotated": false},
+		options.FindOne().SetSort(bson.D{{Key: "start_time", Value: 1}}),
+	).Decode(&gw)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gw, nil
+}
+
+// Locked reports whether playing XI edits are closed for the auction at now.
+// Edits are closed between the lock deadline and the start of the next
+// gameweek. Auctions without scheduled gameweeks are never locked.
+func Locked(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, now time.Time) (bool, *models.Gameweek, error) {
+	gw, err := NextOpen(ctx, db, auctionID)
+	if err != nil || gw == nil {
+		return false, gw, err
+	}
+	return !now.Before(gw.LockDeadline), gw, nil
+}
+
+// RotateXI rotates the auction's XI by hand: every player moves into the
+// next gameweek as in a scheduled rotation, and the next open gameweek, if
+// any, is marked as started so the scheduler does not rotate again. It all
+// happens in one transaction.
+func RotateXI(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, now time.Time) (int64, error) {
+	var updated int64
+	err := inTransaction(ctx, db, func(sc mongo.SessionContext) error {
+		var err error
+		if updated, err = rotateXI(sc, db, auctionID); err != nil {
+			return err
+		}
+		gw, err := NextOpen(sc, db, auctionID)
+		if err != nil || gw == nil {
+			return err
+		}
+		_, err = db.Collection(constants.GameweekCollection).UpdateOne(sc,
+			bson.M{"_id": gw.ID, "rotated": false},
+			bson.M{"$set": bson.M{"rotated": true, "rotated_at": now, "updated_at": now}},
+		)
+		return err
+	})
+	return updated, err
+}
+
+// rotateXI moves every player of the auction into the next gameweek:
+// cumulative points become the carried-over points, the saved XI becomes the
+// current XI and the match slots are cleared. The auction's gameweek counter
+// is advanced so ledger entries know which XI they counted for. The state
+// before rotating is saved as a snapshot of the closing gameweek. Callers
+// run it in a transaction.
+func rotateXI(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (int64, error) {
+	matchIDs, err := auctionMatchIDs(ctx, db, auctionID)
+	if err != nil {
+		return 0, err
+	}
+	if len(matchIDs) == 0 {
+		return 0, nil
+	}
+
+	// Keep the state of the closing gameweek so it can be rolled back to.
+	if err := takeSnapshot(ctx, db, auctionID, matchIDs); err != nil {
+		return 0, err
+	}
+
+	// Single aggregation-pipeline UpdateMany:
+	//    Accumulate: prev* = current cumulative values
+	//    Reset matches array to zeros for new week
+	//    Rotate XI: prevX1←currentX1, currentX1←nextX1, nextX1 kept
+	//    earnedPoints/benchedPoints stay unchanged (= new prev values, since matches reset)
+	updatePipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "prevEarnedPoints", Value: "$earnedPoints"},
+			{Key: "prevBenchedPoints", Value: "$benchedPoints"},
+			{Key: "prevTotalPoints", Value: "$totalPoints"},
+			{Key: "prevX1", Value: "$currentX1"},
+			{Key: "currentX1", Value: "$nextX1"},
+			{Key: "nextX1", Value: "$nextX1"},
+			{Key: "matches", Value: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		}}},
+	}
+
+	result, err := db.Collection(constants.MatchCollection).UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": matchIDs}},
+		updatePipeline,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+		bson.M{"_id": auctionID},
+		bson.M{"$inc": bson.M{"current_gameweek": 1}},
+	)
+	return result.ModifiedCount, err
+}
+
+// RotateDue rotates the XI of every auction whose next gameweek has started
+// by now. It returns the gameweeks that were rotated.
+func RotateDue(ctx context.Context, db *mongo.Database, now time.Time) ([]models.Gameweek, error) {
+	return rotateDue(ctx, db, bson.M{}, now)
+}
+
+// RotateAuction is RotateDue for a single auction. Every request handler
+// that reads or writes the XI or the points calls it first, so an auction is
+// up to date even where the scheduler does not run, such as the serverless
+// deployment.
+func RotateAuction(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, now time.Time) ([]models.Gameweek, error) {
+	return rotateDue(ctx, db, bson.M{"auction_id": auctionID}, now)
+}
+
+func rotateDue(ctx context.Context, db *mongo.Database, filter bson.M, now time.Time) ([]models.Gameweek, error) {
+	filter["rotated"] = false
+	filter["start_time"] = bson.M{"$lte": now}
+
+	cursor, err := db.Collection(constants.GameweekCollection).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var due []models.Gameweek
+	if err := cursor.All(ctx, &due); err != nil {
+		return nil, err
+	}
+
+	rotated := make([]models.Gameweek, 0, len(due))
+	for _, gw := range due {
+		// The claim, snapshot, rotation and counter commit together, so a
+		// failure leaves the gameweek unclaimed and untouched for the next
+		// call, and concurrent callers rotate it once.
+		claimed := false
+		err := inTransaction(ctx, db, func(sc mongo.SessionContext) error {
+			res, err := db.Collection(constants.GameweekCollection).UpdateOne(sc,
+				bson.M{"_id": gw.ID, "rotated": false},
+				bson.M{"$set": bson.M{"rotated": true, "rotated_at": now, "updated_at": now}},
+			)
+			if err != nil {
+				return err
+			}
+			claimed = res.ModifiedCount > 0
+			if !claimed {
+				return nil
+			}
+			_, err = rotateXI(sc, db, gw.AuctionID)
+			return err
+		})
+		if err != nil {
+			return rotated, err
+		}
+		if !claimed {
+			continue
+		}
+		gw.Rotated = true
+		gw.RotatedAt = now
+		rotated = append(rotated, gw)
+	}
+	return rotated, nil
+}
+
+// auctionMatchIDs returns the Match document IDs of the auction's players.
+func auctionMatchIDs(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx,
+		bson.M{
+			"auction_id": auctionID,
+			"match":      bson.M{"$exists": true},
+		},
+		options.Find().SetProjection(bson.M{"match": 1, "_id": 0}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	type matchRef struct {
+		Match primitive.ObjectID `bson:"match"`
+	}
+	var refs []matchRef
+	if err := cursor.All(ctx, &refs); err != nil {
+		return nil, err
+	}
+
+	matchIDs := make([]primitive.ObjectID, 0, len(refs))
+	for _, r := range refs {
+		if !r.Match.IsZero() {
+			matchIDs = append(matchIDs, r.Match)
+		}
+	}
+	return matchIDs, nil
+}
+
+// inTransaction runs fn in a transaction, retried by the driver on transient
+// errors such as a write conflict with a concurrent call.
+func inTransaction(ctx context.Context, db *mongo.Database, fn func(sc mongo.SessionContext) error) error {
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
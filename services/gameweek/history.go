@@ -0,0 +1,298 @@
+package gameweek
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/leaderboard"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNoSnapshot is returned when there is no snapshot of the requested
+// gameweek to roll back to.
+var ErrNoSnapshot = errors.New("no snapshot for gameweek")
+
+// PlayerChange is how rolling back changes one player's Match document.
+type PlayerChange struct {
+	MatchID       primitive.ObjectID `json:"match_id"`
+	PlayerName    string             `json:"player_name"`
+	EarnedFrom    int                `json:"earned_from"`
+	EarnedTo      int                `json:"earned_to"`
+	BenchedFrom   int                `json:"benched_from"`
+	BenchedTo     int                `json:"benched_to"`
+	TotalFrom     int                `json:"total_from"`
+	TotalTo       int                `json:"total_to"`
+	CurrentX1From bool               `json:"current_x1_from"`
+	CurrentX1To   bool               `json:"current_x1_to"`
+	NextX1From    bool               `json:"next_x1_from"`
+	NextX1To      bool               `json:"next_x1_to"`
+}
+
+// Rollback describes rolling an auction back to the end of a gameweek.
+type Rollback struct {
+	FromGameweek int            `json:"from_gameweek"`
+	ToGameweek   int            `json:"to_gameweek"`
+	Players      []PlayerChange `json:"players"`
+	// LedgerEntries is how many applied ledger entries of later gameweeks
+	// stop counting.
+	LedgerEntries int64 `json:"ledger_entries"`
+}
+
+// takeSnapshot saves the Match documents of the auction, and the leaderboard
+// they make, as the state at the end of its current gameweek.
+func takeSnapshot(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, matchIDs []primitive.ObjectID) error {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Collection(constants.MatchCollection).Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+	if err != nil {
+		return err
+	}
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return err
+	}
+
+	snap := models.XISnapshot{
+		AuctionID: auctionID,
+		Gameweek:  auction.CurrentGameweek,
+		Matches:   matches,
+		CreatedAt: time.Now(),
+	}
+	_, err = db.Collection(constants.XISnapshotCollection).ReplaceOne(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": snap.Gameweek},
+		snap,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+	return leaderboard.Snapshot(ctx, db, auctionID, snap.Gameweek, matches)
+}
+
+// Snapshots lists the snapshots of an auction, newest first, without the
+// Match documents.
+func Snapshots(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]models.XISnapshot, error) {
+	cursor, err := db.Collection(constants.XISnapshotCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().
+			SetSort(bson.D{{Key: "gameweek", Value: -1}}).
+			SetProjection(bson.M{"matches": 0}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	snaps := []models.XISnapshot{}
+	if err := cursor.All(ctx, &snaps); err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
+
+// PreviewRollback reports what rolling the auction back to the end of
+// gameweek would change, without changing anything.
+func PreviewRollback(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int) (*Rollback, error) {
+	rb, _, err := planRollback(ctx, db, auctionID, gameweek)
+	return rb, err
+}
+
+// RollbackTo restores the auction's Match documents from the snapshot of
+// gameweek, makes it the current gameweek again, un-applies ledger entries
+// of later gameweeks and drops the XI and leaderboard snapshots that are now
+// in the future. Gameweeks after it are marked as not rotated so the
+// scheduler rotates them again. The writes run in a single transaction.
+func RollbackTo(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int) (*Rollback, error) {
+	rb, snap, err := planRollback(ctx, db, auctionID, gameweek)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]mongo.WriteModel, 0, len(snap.Matches))
+	for _, m := range snap.Matches {
+		ops = append(ops, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": m.Id}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"matches":           m.Matches,
+				"prevX1":            m.PrevX1,
+				"currentX1":         m.CurrentX1,
+				"nextX1":            m.NextX1,
+				"earnedPoints":      m.EarnedPoints,
+				"benchedPoints":     m.BenchedPoints,
+				"totalPoints":       m.TotalPoints,
+				"prevEarnedPoints":  m.PrevEarnedPoints,
+				"prevBenchedPoints": m.PrevBenchedPoints,
+				"prevTotalPoints":   m.PrevTotalPoints,
+			}}))
+	}
+
+	// All writes go in one transaction so a failure part way never leaves
+	// the squads restored while the ledger or gameweeks still say otherwise.
+	err = inTransaction(ctx, db, func(sc mongo.SessionContext) error {
+		return rollbackWrites(sc, db, auctionID, gameweek, ops)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rb, nil
+}
+
+func rollbackWrites(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int, ops []mongo.WriteModel) error {
+	if len(ops) > 0 {
+		if _, err := db.Collection(constants.MatchCollection).BulkWrite(ctx, ops); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Collection(constants.LedgerCollection).UpdateMany(ctx,
+		bson.M{"auction_id": auctionID, "applied": true, "gameweek": bson.M{"$gt": gameweek}},
+		bson.M{
+			"$set":   bson.M{"applied": false},
+			"$unset": bson.M{"points": "", "match_index": "", "gameweek": "", "counted_as": "", "applied_at": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(constants.AuctionCollection).UpdateOne(ctx,
+		bson.M{"_id": auctionID},
+		bson.M{"$set": bson.M{"current_gameweek": gameweek}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Later gameweeks must rotate again once the auction replays them.
+	_, err = db.Collection(constants.GameweekCollection).UpdateMany(ctx,
+		bson.M{"auction_id": auctionID, "number": bson.M{"$gt": gameweek}, "rotated": true},
+		bson.M{
+			"$set":   bson.M{"rotated": false, "updated_at": time.Now()},
+			"$unset": bson.M{"rotated_at": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(constants.XISnapshotCollection).DeleteMany(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": bson.M{"$gte": gameweek}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(constants.LeaderboardCollection).DeleteMany(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": bson.M{"$gte": gameweek}},
+	)
+	return err
+}
+
+func planRollback(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int) (*Rollback, *models.XISnapshot, error) {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gameweek < 0 || gameweek >= auction.CurrentGameweek {
+		return nil, nil, ErrNoSnapshot
+	}
+
+	var snap models.XISnapshot
+	err = db.Collection(constants.XISnapshotCollection).FindOne(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": gameweek},
+	).Decode(&snap)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil, ErrNoSnapshot
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matchIDs := make([]primitive.ObjectID, 0, len(snap.Matches))
+	for _, m := range snap.Matches {
+		matchIDs = append(matchIDs, m.Id)
+	}
+
+	cursor, err := db.Collection(constants.MatchCollection).Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+	if err != nil {
+		return nil, nil, err
+	}
+	var current []models.Match
+	if err := cursor.All(ctx, &current); err != nil {
+		return nil, nil, err
+	}
+	now := make(map[primitive.ObjectID]models.Match, len(current))
+	for _, m := range current {
+		now[m.Id] = m
+	}
+
+	names, err := playerNames(ctx, db, matchIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rb := &Rollback{
+		FromGameweek: auction.CurrentGameweek,
+		ToGameweek:   gameweek,
+		Players:      []PlayerChange{},
+	}
+	for _, to := range snap.Matches {
+		from := now[to.Id]
+		if from.EarnedPoints == to.EarnedPoints && from.BenchedPoints == to.BenchedPoints &&
+			from.TotalPoints == to.TotalPoints && from.CurrentX1 == to.CurrentX1 && from.NextX1 == to.NextX1 {
+			continue
+		}
+		rb.Players = append(rb.Players, PlayerChange{
+			MatchID:       to.Id,
+			PlayerName:    names[to.Id],
+			EarnedFrom:    from.EarnedPoints,
+			EarnedTo:      to.EarnedPoints,
+			BenchedFrom:   from.BenchedPoints,
+			BenchedTo:     to.BenchedPoints,
+			TotalFrom:     from.TotalPoints,
+			TotalTo:       to.TotalPoints,
+			CurrentX1From: from.CurrentX1,
+			CurrentX1To:   to.CurrentX1,
+			NextX1From:    from.NextX1,
+			NextX1To:      to.NextX1,
+		})
+	}
+
+	rb.LedgerEntries, err = db.Collection(constants.LedgerCollection).CountDocuments(ctx,
+		bson.M{"auction_id": auctionID, "applied": true, "gameweek": bson.M{"$gt": gameweek}},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rb, &snap, nil
+}
+
+// playerNames maps Match document IDs to the names of their players.
+func playerNames(ctx context.Context, db *mongo.Database, matchIDs []primitive.ObjectID) (map[primitive.ObjectID]string, error) {
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx,
+		bson.M{"match": bson.M{"$in": matchIDs}},
+		options.Find().SetProjection(bson.M{"match": 1, "player_name": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+
+	names := make(map[primitive.ObjectID]string, len(players))
+	for _, p := range players {
+		names[p.Match] = p.PlayerName
+	}
+	return names, nil
+}
@@ -0,0 +1,58 @@
+package gameweek
+
+import (
+	"context"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// Scheduler rotates gameweeks in the background as their start time passes.
+// It only runs in the long-lived server (main.go). The serverless deployment
+// (api/index.go) has no background work: there, an external cron calls
+// GET /api/v1/cron/gameweeks/rotate and request handlers rotate their
+// auction on demand.
+type Scheduler struct {
+	logger   *zap.Logger
+	db       *mongo.Database
+	interval time.Duration
+}
+
+// NewScheduler returns a scheduler that checks for due gameweeks every minute.
+func NewScheduler(logger *zap.Logger, db *mongo.Database) *Scheduler {
+	return &Scheduler{logger: logger, db: db, interval: time.Minute}
+}
+
+// Run checks for due gameweeks until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DBTimeout)
+	defer cancel()
+
+	rotated, err := RotateDue(ctx, s.db, time.Now())
+	if err != nil {
+		s.logger.Error("failed to rotate due gameweeks", zap.Error(err))
+	}
+	for _, gw := range rotated {
+		s.logger.Info("gameweek started",
+			zap.String("auction_id", gw.AuctionID.Hex()),
+			zap.Int("gameweek", gw.Number),
+		)
+	}
+}
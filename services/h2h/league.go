@@ -0,0 +1,195 @@
+package h2h
+
+import (
+	"context"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/leaderboard"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LoadRules reads the h2h_rules of an auction on top of the default rules.
+func LoadRules(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (Rules, error) {
+	doc := struct {
+		H2HRules Rules `bson:"h2h_rules"`
+	}{H2HRules: DefaultRules()}
+
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx,
+		bson.M{"_id": auctionID},
+		options.FindOne().SetProjection(bson.M{"h2h_rules": 1}),
+	).Decode(&doc)
+	return doc.H2HRules, err
+}
+
+// Generate replaces the auction's fixtures from startGameweek on with a
+// round-robin schedule of the given number of cycles. Earlier fixtures are
+// kept so results already played stay in the table.
+func Generate(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, startGameweek, cycles int) ([]models.H2HFixture, error) {
+	cursor, err := db.Collection(constants.TeamCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var teams []models.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(teams))
+	for _, t := range teams {
+		ids = append(ids, t.ID)
+	}
+
+	fixtures := []models.H2HFixture{}
+	now := time.Now()
+	for r, round := range RoundRobin(ids, cycles) {
+		for _, p := range round {
+			fixtures = append(fixtures, models.H2HFixture{
+				AuctionID:  auctionID,
+				Gameweek:   startGameweek + r,
+				HomeTeamID: p.Home,
+				AwayTeamID: p.Away,
+				CreatedAt:  now,
+			})
+		}
+	}
+
+	coll := db.Collection(constants.H2HFixtureCollection)
+	_, err = coll.DeleteMany(ctx, bson.M{"auction_id": auctionID, "gameweek": bson.M{"$gte": startGameweek}})
+	if err != nil {
+		return nil, err
+	}
+	if len(fixtures) == 0 {
+		return fixtures, nil
+	}
+
+	docs := make([]any, 0, len(fixtures))
+	for _, f := range fixtures {
+		docs = append(docs, f)
+	}
+	res, err := coll.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range res.InsertedIDs {
+		fixtures[i].ID = id.(primitive.ObjectID)
+	}
+	return fixtures, nil
+}
+
+// Load returns every fixture of the auction with its result and the
+// standings from the played ones. A gameweek's score is the team's earned
+// points at its close minus those at the previous close, taken from the
+// leaderboard history; the current gameweek is scored live.
+func Load(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]Result, []Row, error) {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := LoadRules(ctx, db, auctionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cursor, err := db.Collection(constants.H2HFixtureCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "gameweek", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	var fixtures []models.H2HFixture
+	if err := cursor.All(ctx, &fixtures); err != nil {
+		return nil, nil, err
+	}
+
+	// earned[gameweek][team] is the team's earned points at that close.
+	earned := make(map[int]map[primitive.ObjectID]int)
+	history, err := leaderboard.History(ctx, db, auctionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, s := range history {
+		if s.Gameweek < auction.CurrentGameweek {
+			earned[s.Gameweek] = earnedByTeam(s.Teams)
+		}
+	}
+	live, err := leaderboard.Current(ctx, db, auctionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	earned[auction.CurrentGameweek] = earnedByTeam(live)
+
+	names := make(map[primitive.ObjectID]string, len(live))
+	teams := make([]Row, 0, len(live))
+	for _, r := range live {
+		names[r.TeamID] = r.TeamName
+		teams = append(teams, Row{TeamID: r.TeamID, TeamName: r.TeamName})
+	}
+
+	scored := func(gw int, team primitive.ObjectID) (int, bool) {
+		end, ok := earned[gw]
+		if !ok {
+			return 0, false
+		}
+		if gw == 0 {
+			return end[team], true
+		}
+		start, ok := earned[gw-1]
+		if !ok {
+			return 0, false
+		}
+		return end[team] - start[team], true
+	}
+
+	results := make([]Result, 0, len(fixtures))
+	for _, f := range fixtures {
+		res := Result{
+			FixtureID:    f.ID,
+			Gameweek:     f.Gameweek,
+			HomeTeamID:   f.HomeTeamID,
+			HomeTeamName: names[f.HomeTeamID],
+			AwayTeamID:   f.AwayTeamID,
+			AwayTeamName: names[f.AwayTeamID],
+		}
+		switch {
+		case f.Gameweek > auction.CurrentGameweek:
+			res.Status = StatusUpcoming
+		case f.AwayTeamID.IsZero():
+			res.Status = StatusBye
+		default:
+			home, okHome := scored(f.Gameweek, f.HomeTeamID)
+			away, okAway := scored(f.Gameweek, f.AwayTeamID)
+			res.HomePoints, res.AwayPoints = home, away
+			switch {
+			case !okHome || !okAway:
+				res.Status = StatusUnavailable
+			case f.Gameweek == auction.CurrentGameweek:
+				res.Status = StatusLive
+			default:
+				res.Status = StatusPlayed
+			}
+		}
+		results = append(results, res)
+	}
+
+	return results, Table(teams, results, rules), nil
+}
+
+func earnedByTeam(rows []models.LeaderboardRow) map[primitive.ObjectID]int {
+	m := make(map[primitive.ObjectID]int, len(rows))
+	for _, r := range rows {
+		m[r.TeamID] = r.EarnedPoints
+	}
+	return m
+}
@@ -0,0 +1,61 @@
+// Package h2h runs head-to-head leagues: a round-robin fixture list between
+// the fantasy teams of an auction and a standings table from each
+// gameweek's earned points.
+package h2h
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Pair is one fixture of a round. Away is zero for a bye.
+type Pair struct {
+	Home primitive.ObjectID
+	Away primitive.ObjectID
+}
+
+// RoundRobin returns rounds of fixtures in which every team meets every
+// other team once per cycle, using the circle method. Cycles alternate home
+// and away. With an odd number of teams one team per round has a bye.
+func RoundRobin(teams []primitive.ObjectID, cycles int) [][]Pair {
+	if len(teams) < 2 || cycles < 1 {
+		return nil
+	}
+
+	ring := append([]primitive.ObjectID{}, teams...)
+	if len(ring)%2 == 1 {
+		ring = append(ring, primitive.NilObjectID)
+	}
+	n := len(ring)
+
+	var rounds [][]Pair
+	for cycle := 0; cycle < cycles; cycle++ {
+		order := append([]primitive.ObjectID{}, ring...)
+		for r := 0; r < n-1; r++ {
+			round := make([]Pair, 0, n/2)
+			for i := 0; i < n/2; i++ {
+				home, away := order[i], order[n-1-i]
+				// Swap the fixed team's home games so no team is always at
+				// home.
+				if i == 0 && r%2 == 1 {
+					home, away = away, home
+				}
+				if cycle%2 == 1 {
+					home, away = away, home
+				}
+				switch {
+				case home.IsZero():
+					round = append(round, Pair{Home: away})
+				case away.IsZero():
+					round = append(round, Pair{Home: home})
+				default:
+					round = append(round, Pair{Home: home, Away: away})
+				}
+			}
+			rounds = append(rounds, round)
+
+			// Keep the first team fixed and rotate the rest one place.
+			last := order[n-1]
+			copy(order[2:], order[1:n-1])
+			order[1] = last
+		}
+	}
+	return rounds
+}
@@ -0,0 +1,190 @@
+package h2h
+
+import (
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Result statuses.
+const (
+	StatusPlayed      = "played"
+	StatusLive        = "live"
+	StatusUpcoming    = "upcoming"
+	StatusBye         = "bye"
+	StatusUnavailable = "unavailable"
+)
+
+// Rules are the league points for a head-to-head result.
+type Rules struct {
+	Win  int `bson:"win" json:"win"`
+	Draw int `bson:"draw" json:"draw"`
+	Loss int `bson:"loss" json:"loss"`
+}
+
+// DefaultRules awards 3 for a win, 1 for a draw and nothing for a loss.
+func DefaultRules() Rules {
+	return Rules{Win: 3, Draw: 1, Loss: 0}
+}
+
+// Result is a fixture with the earned points each team scored in it.
+type Result struct {
+	FixtureID    primitive.ObjectID `json:"fixture_id"`
+	Gameweek     int                `json:"gameweek"`
+	HomeTeamID   primitive.ObjectID `json:"home_team_id"`
+	HomeTeamName string             `json:"home_team_name"`
+	AwayTeamID   primitive.ObjectID `json:"away_team_id,omitempty"`
+	AwayTeamName string             `json:"away_team_name,omitempty"`
+	HomePoints   int                `json:"home_points"`
+	AwayPoints   int                `json:"away_points"`
+	Status       string             `json:"status"`
+}
+
+// Row is a team's line in the standings.
+type Row struct {
+	Rank          int                `json:"rank"`
+	TeamID        primitive.ObjectID `json:"team_id"`
+	TeamName      string             `json:"team_name"`
+	Played        int                `json:"played"`
+	Won           int                `json:"won"`
+	Drawn         int                `json:"drawn"`
+	Lost          int                `json:"lost"`
+	Byes          int                `json:"byes"`
+	PointsFor     int                `json:"points_for"`
+	PointsAgainst int                `json:"points_against"`
+	LeaguePoints  int                `json:"league_points"`
+}
+
+// Table builds the standings from played results. Teams are ordered by
+// league points, then fantasy points scored, then points difference, then
+// head-to-head league points in games among the tied teams, then name.
+// Teams level on all of these share a rank.
+func Table(teams []Row, results []Result, rules Rules) []Row {
+	rows := make(map[primitive.ObjectID]*Row, len(teams))
+	order := make([]primitive.ObjectID, 0, len(teams))
+	for _, t := range teams {
+		t := t
+		rows[t.TeamID] = &t
+		order = append(order, t.TeamID)
+	}
+	row := func(id primitive.ObjectID) *Row {
+		r := rows[id]
+		if r == nil {
+			r = &Row{TeamID: id}
+			rows[id] = r
+			order = append(order, id)
+		}
+		return r
+	}
+
+	// meetings[a][b] are the league points a took from games against b.
+	meetings := make(map[primitive.ObjectID]map[primitive.ObjectID]int)
+	award := func(a, b primitive.ObjectID, pts int) {
+		if meetings[a] == nil {
+			meetings[a] = make(map[primitive.ObjectID]int)
+		}
+		meetings[a][b] += pts
+	}
+
+	for _, res := range results {
+		if res.Status == StatusBye {
+			row(res.HomeTeamID).Byes++
+			continue
+		}
+		if res.Status != StatusPlayed {
+			continue
+		}
+		home, away := row(res.HomeTeamID), row(res.AwayTeamID)
+		home.Played++
+		away.Played++
+		home.PointsFor += res.HomePoints
+		home.PointsAgainst += res.AwayPoints
+		away.PointsFor += res.AwayPoints
+		away.PointsAgainst += res.HomePoints
+
+		switch {
+		case res.HomePoints > res.AwayPoints:
+			home.Won++
+			away.Lost++
+			home.LeaguePoints += rules.Win
+			away.LeaguePoints += rules.Loss
+			award(home.TeamID, away.TeamID, rules.Win)
+			award(away.TeamID, home.TeamID, rules.Loss)
+		case res.HomePoints < res.AwayPoints:
+			away.Won++
+			home.Lost++
+			away.LeaguePoints += rules.Win
+			home.LeaguePoints += rules.Loss
+			award(away.TeamID, home.TeamID, rules.Win)
+			award(home.TeamID, away.TeamID, rules.Loss)
+		default:
+			home.Drawn++
+			away.Drawn++
+			home.LeaguePoints += rules.Draw
+			away.LeaguePoints += rules.Draw
+			award(home.TeamID, away.TeamID, rules.Draw)
+			award(away.TeamID, home.TeamID, rules.Draw)
+		}
+	}
+
+	table := make([]Row, 0, len(order))
+	for _, id := range order {
+		table = append(table, *rows[id])
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if a.LeaguePoints != b.LeaguePoints {
+			return a.LeaguePoints > b.LeaguePoints
+		}
+		if a.PointsFor != b.PointsFor {
+			return a.PointsFor > b.PointsFor
+		}
+		if da, db := a.PointsFor-a.PointsAgainst, b.PointsFor-b.PointsAgainst; da != db {
+			return da > db
+		}
+		return a.TeamName < b.TeamName
+	})
+
+	// Teams still level are ordered by a mini-table of the league points
+	// they took in games among themselves only; teams level in it too share
+	// a rank.
+	level := func(a, b Row) bool {
+		return a.LeaguePoints == b.LeaguePoints &&
+			a.PointsFor == b.PointsFor &&
+			a.PointsFor-a.PointsAgainst == b.PointsFor-b.PointsAgainst
+	}
+	rank := 1
+	for _, group := range split(table, level) {
+		mini := make(map[primitive.ObjectID]int, len(group))
+		for _, a := range group {
+			for _, b := range group {
+				mini[a.TeamID] += meetings[a.TeamID][b.TeamID]
+			}
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return mini[group[i].TeamID] > mini[group[j].TeamID]
+		})
+		for _, tied := range split(group, func(a, b Row) bool { return mini[a.TeamID] == mini[b.TeamID] }) {
+			for i := range tied {
+				tied[i].Rank = rank
+			}
+			rank += len(tied)
+		}
+	}
+	return table
+}
+
+// split cuts an ordered slice into runs of rows that are the same as the
+// first row of their run. The runs share the slice's backing array.
+func split(rows []Row, same func(a, b Row) bool) [][]Row {
+	var groups [][]Row
+	start := 0
+	for i := 1; i <= len(rows); i++ {
+		if i == len(rows) || !same(rows[start], rows[i]) {
+			groups = append(groups, rows[start:i])
+			start = i
+		}
+	}
+	return groups
+}
@@ -0,0 +1,82 @@
+package h2h
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// win is a played fixture the home team wins 60-50.
+func win(ids map[string]primitive.ObjectID, home, away string) Result {
+	return Result{
+		HomeTeamID: ids[home],
+		AwayTeamID: ids[away],
+		HomePoints: 60,
+		AwayPoints: 50,
+		Status:     StatusPlayed,
+	}
+}
+
+func TestTableHeadToHead(t *testing.T) {
+	tests := []struct {
+		name    string
+		teams   []string // input order
+		results func(ids map[string]primitive.ObjectID) []Result
+		want    map[string]int
+	}{
+		{
+			// A beat B, B beat C, C beat A: all level, whatever the order.
+			name:  "cycle shares a rank",
+			teams: []string{"A", "B", "C"},
+			results: func(ids map[string]primitive.ObjectID) []Result {
+				return []Result{win(ids, "A", "B"), win(ids, "B", "C"), win(ids, "C", "A")}
+			},
+			want: map[string]int{"A": 1, "B": 1, "C": 1},
+		},
+		{
+			name:  "cycle in another order",
+			teams: []string{"C", "A", "B"},
+			results: func(ids map[string]primitive.ObjectID) []Result {
+				return []Result{win(ids, "C", "A"), win(ids, "B", "C"), win(ids, "A", "B")}
+			},
+			want: map[string]int{"A": 1, "B": 1, "C": 1},
+		},
+		{
+			// A, B and C are 2-2 with the same fantasy points; only their
+			// games against each other count, not those against D.
+			name:  "mini-table of the tied teams",
+			teams: []string{"C", "B", "A", "D"},
+			results: func(ids map[string]primitive.ObjectID) []Result {
+				return []Result{
+					win(ids, "A", "B"), win(ids, "A", "C"), win(ids, "B", "C"),
+					win(ids, "D", "A"), win(ids, "D", "A"),
+					win(ids, "B", "D"), win(ids, "D", "B"),
+					win(ids, "C", "D"), win(ids, "C", "D"),
+				}
+			},
+			want: map[string]int{"D": 1, "A": 2, "B": 3, "C": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make(map[string]primitive.ObjectID)
+			teams := make([]Row, 0, len(tt.teams))
+			for _, n := range tt.teams {
+				ids[n] = primitive.NewObjectID()
+				teams = append(teams, Row{TeamID: ids[n], TeamName: n})
+			}
+
+			table := Table(teams, tt.results(ids), DefaultRules())
+			got := make(map[string]int, len(table))
+			for _, r := range table {
+				got[r.TeamName] = r.Rank
+			}
+			for name, rank := range tt.want {
+				if got[name] != rank {
+					t.Errorf("ranks = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
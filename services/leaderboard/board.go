@@ -0,0 +1,148 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrNoGameweek is returned for a gameweek that has no saved leaderboard.
+var ErrNoGameweek = errors.New("no leaderboard for gameweek")
+
+// Standing is a team's row with its movement over the gameweek.
+type Standing struct {
+	models.LeaderboardRow
+	// RankChange is positive when the team moved up since the last
+	// gameweek.
+	RankChange     int `json:"rank_change"`
+	PreviousRank   int `json:"previous_rank"`
+	GameweekPoints int `json:"gameweek_points"`
+}
+
+// ChartPoint is a team's rank at the close of a gameweek.
+type ChartPoint struct {
+	Gameweek     int `json:"gameweek"`
+	Rank         int `json:"rank"`
+	EarnedPoints int `json:"earned_points"`
+}
+
+// Series is one team's line of the season rank chart.
+type Series struct {
+	TeamID   primitive.ObjectID `json:"team_id"`
+	TeamName string             `json:"team_name"`
+	Points   []ChartPoint       `json:"points"`
+}
+
+// Board is the leaderboard at a gameweek with movement and the rank chart
+// up to that gameweek.
+type Board struct {
+	Gameweek  int        `json:"gameweek"`
+	Live      bool       `json:"live"`
+	Standings []Standing `json:"standings"`
+	Chart     []Series   `json:"chart"`
+}
+
+// Load builds the board of the auction at gameweek, or the live board of the
+// current gameweek when gameweek is nil.
+func Load(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek *int) (*Board, error) {
+	var auction models.Auction
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx, bson.M{"_id": auctionID}).Decode(&auction)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := History(ctx, db, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &Board{Gameweek: auction.CurrentGameweek}
+	if gameweek != nil && *gameweek != auction.CurrentGameweek {
+		board.Gameweek = *gameweek
+	} else {
+		board.Live = true
+		rows, err := Current(ctx, db, auctionID)
+		if err != nil {
+			return nil, err
+		}
+		// Earlier closes of the live gameweek (undone by a rollback) are
+		// replaced by the live rows.
+		kept := history[:0]
+		for _, s := range history {
+			if s.Gameweek < board.Gameweek {
+				kept = append(kept, s)
+			}
+		}
+		history = append(kept, models.LeaderboardSnapshot{Gameweek: board.Gameweek, Teams: rows})
+	}
+
+	var rows, prev []models.LeaderboardRow
+	found := false
+	for _, s := range history {
+		if s.Gameweek == board.Gameweek-1 {
+			prev = s.Teams
+		}
+		if s.Gameweek == board.Gameweek {
+			rows = s.Teams
+			found = true
+		}
+	}
+	if !found {
+		return nil, ErrNoGameweek
+	}
+
+	board.Standings = standings(rows, prev)
+	board.Chart = chart(history, board.Gameweek)
+	return board, nil
+}
+
+func standings(rows, prev []models.LeaderboardRow) []Standing {
+	before := make(map[primitive.ObjectID]models.LeaderboardRow, len(prev))
+	for _, r := range prev {
+		before[r.TeamID] = r
+	}
+
+	out := make([]Standing, 0, len(rows))
+	for _, r := range rows {
+		s := Standing{LeaderboardRow: r, GameweekPoints: r.EarnedPoints}
+		if p, ok := before[r.TeamID]; ok {
+			s.PreviousRank = p.Rank
+			s.RankChange = p.Rank - r.Rank
+			s.GameweekPoints = r.EarnedPoints - p.EarnedPoints
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func chart(history []models.LeaderboardSnapshot, upTo int) []Series {
+	var order []primitive.ObjectID
+	series := make(map[primitive.ObjectID]*Series)
+	for _, s := range history {
+		if s.Gameweek > upTo {
+			break
+		}
+		for _, r := range s.Teams {
+			line := series[r.TeamID]
+			if line == nil {
+				line = &Series{TeamID: r.TeamID, Points: []ChartPoint{}}
+				series[r.TeamID] = line
+				order = append(order, r.TeamID)
+			}
+			line.TeamName = r.TeamName
+			line.Points = append(line.Points, ChartPoint{Gameweek: s.Gameweek, Rank: r.Rank, EarnedPoints: r.EarnedPoints})
+		}
+	}
+
+	out := make([]Series, 0, len(order))
+	for _, id := range order {
+		out = append(out, *series[id])
+	}
+	return out
+}
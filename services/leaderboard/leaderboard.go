@@ -0,0 +1,227 @@
+// Package leaderboard ranks the fantasy teams of an auction, keeps the
+// leaderboard of every closed gameweek and builds rank movement from them.
+package leaderboard
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Current returns the live standings of the auction, ranked.
+func Current(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]models.LeaderboardRow, error) {
+	// Single aggregation pipeline: teams → lookup players → lookup matches → group
+	pipeline := mongo.Pipeline{
+		// Match teams in this auction
+		{{Key: "$match", Value: bson.M{"auction_id": auctionID}}},
+
+		// Lookup players from squad
+		{{Key: "$lookup", Value: bson.M{
+			"from":         constants.PlayerCollection,
+			"localField":   "squad",
+			"foreignField": "_id",
+			"as":           "players",
+		}}},
+
+		// Unwind players (preserve teams with empty squads)
+		{{Key: "$unwind", Value: bson.M{
+			"path":                       "$players",
+			"preserveNullAndEmptyArrays": true,
+		}}},
+
+		// Lookup match for each player
+		{{Key: "$lookup", Value: bson.M{
+			"from":         constants.MatchCollection,
+			"localField":   "players.match",
+			"foreignField": "_id",
+			"as":           "match",
+		}}},
+
+		// Unwind match
+		{{Key: "$unwind", Value: bson.M{
+			"path":                       "$match",
+			"preserveNullAndEmptyArrays": true,
+		}}},
+
+		// Group by team — sum points across all squad players
+		{{Key: "$group", Value: bson.M{
+			"_id":            "$_id",
+			"team_name":      bson.M{"$first": "$team_name"},
+			"team_image":     bson.M{"$first": "$team_image"},
+			"earned_points":  bson.M{"$sum": bson.M{"$ifNull": []any{"$match.earnedPoints", 0}}},
+			"benched_points": bson.M{"$sum": bson.M{"$ifNull": []any{"$match.benchedPoints", 0}}},
+			"total_points":   bson.M{"$sum": bson.M{"$ifNull": []any{"$match.totalPoints", 0}}},
+		}}},
+
+		// Clean up output
+		{{Key: "$project", Value: bson.M{
+			"_id":            0,
+			"team_id":        "$_id",
+			"team_name":      1,
+			"team_image":     1,
+			"earned_points":  1,
+			"benched_points": 1,
+			"total_points":   1,
+		}}},
+	}
+
+	cursor, err := db.Collection(constants.TeamCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	rows := []models.LeaderboardRow{}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	Rank(rows)
+	return rows, nil
+}
+
+// Rank sorts rows by earned points, then benched points, and numbers them.
+// Teams level on both share a rank.
+func Rank(rows []models.LeaderboardRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].EarnedPoints != rows[j].EarnedPoints {
+			return rows[i].EarnedPoints > rows[j].EarnedPoints
+		}
+		return rows[i].BenchedPoints > rows[j].BenchedPoints
+	})
+	for i := range rows {
+		if i > 0 && rows[i].EarnedPoints == rows[i-1].EarnedPoints && rows[i].BenchedPoints == rows[i-1].BenchedPoints {
+			rows[i].Rank = rows[i-1].Rank
+		} else {
+			rows[i].Rank = i + 1
+		}
+	}
+}
+
+// FromMatches ranks the teams using the given Match documents instead of
+// the live ones. matchOf maps player IDs to their Match document IDs.
+func FromMatches(teams []models.Team, matchOf map[primitive.ObjectID]primitive.ObjectID, matches []models.Match) []models.LeaderboardRow {
+	byID := make(map[primitive.ObjectID]models.Match, len(matches))
+	for _, m := range matches {
+		byID[m.Id] = m
+	}
+
+	rows := make([]models.LeaderboardRow, 0, len(teams))
+	for _, t := range teams {
+		row := models.LeaderboardRow{TeamID: t.ID, TeamName: t.TeamName, TeamImage: t.TeamImage}
+		for _, playerID := range t.Squad {
+			m, ok := byID[matchOf[playerID]]
+			if !ok {
+				continue
+			}
+			row.EarnedPoints += m.EarnedPoints
+			row.BenchedPoints += m.BenchedPoints
+			row.TotalPoints += m.TotalPoints
+		}
+		rows = append(rows, row)
+	}
+	Rank(rows)
+	return rows
+}
+
+// Snapshot saves the leaderboard at the close of gameweek, computed from the
+// Match documents as they were at that point.
+func Snapshot(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int, matches []models.Match) error {
+	teams, matchOf, err := loadSquads(ctx, db, auctionID)
+	if err != nil {
+		return err
+	}
+	return save(ctx, db, auctionID, gameweek, FromMatches(teams, matchOf, matches))
+}
+
+// Rebuild recomputes every saved leaderboard of the auction from its XI
+// snapshots, after points of closed gameweeks were corrected.
+func Rebuild(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) error {
+	teams, matchOf, err := loadSquads(ctx, db, auctionID)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Collection(constants.XISnapshotCollection).Find(ctx, bson.M{"auction_id": auctionID})
+	if err != nil {
+		return err
+	}
+	var snaps []models.XISnapshot
+	if err := cursor.All(ctx, &snaps); err != nil {
+		return err
+	}
+
+	for _, s := range snaps {
+		if err := save(ctx, db, auctionID, s.Gameweek, FromMatches(teams, matchOf, s.Matches)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func save(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, gameweek int, rows []models.LeaderboardRow) error {
+	snap := models.LeaderboardSnapshot{
+		AuctionID: auctionID,
+		Gameweek:  gameweek,
+		Teams:     rows,
+		CreatedAt: time.Now(),
+	}
+	_, err := db.Collection(constants.LeaderboardCollection).ReplaceOne(ctx,
+		bson.M{"auction_id": auctionID, "gameweek": gameweek},
+		snap,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// History returns the saved leaderboards of the auction, oldest first.
+func History(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]models.LeaderboardSnapshot, error) {
+	cursor, err := db.Collection(constants.LeaderboardCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "gameweek", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	snaps := []models.LeaderboardSnapshot{}
+	if err := cursor.All(ctx, &snaps); err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
+
+// loadSquads returns the auction's teams and the Match document of every
+// player.
+func loadSquads(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]models.Team, map[primitive.ObjectID]primitive.ObjectID, error) {
+	cursor, err := db.Collection(constants.TeamCollection).Find(ctx, bson.M{"auction_id": auctionID})
+	if err != nil {
+		return nil, nil, err
+	}
+	var teams []models.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, nil, err
+	}
+
+	cursor, err = db.Collection(constants.PlayerCollection).Find(ctx,
+		bson.M{"auction_id": auctionID, "match": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"match": 1}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, nil, err
+	}
+
+	matchOf := make(map[primitive.ObjectID]primitive.ObjectID, len(players))
+	for _, p := range players {
+		matchOf[p.Id] = p.Match
+	}
+	return teams, matchOf, nil
+}
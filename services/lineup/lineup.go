@@ -0,0 +1,190 @@
+// Package lineup checks a playing XI against an auction's selection rules.
+package lineup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Player roles as stored on auction players.
+const (
+	RoleBatter       = "Batter"
+	RoleBowler       = "Bowler"
+	RoleAllRounder   = "All-Rounder"
+	RoleWicketKeeper = "Wicket-Keeper"
+)
+
+// Violation codes.
+const (
+	CodeSize        = "size"
+	CodeNotInSquad  = "not_in_squad"
+	CodeDuplicate   = "duplicate"
+	CodeRoleMin     = "role_min"
+	CodeRoleMax     = "role_max"
+	CodeMaxOverseas = "max_overseas"
+)
+
+// RoleLimit bounds how many players of a role the XI may have. A Max of 0
+// means no upper bound.
+type RoleLimit struct {
+	Min int `bson:"min" json:"min"`
+	Max int `bson:"max" json:"max"`
+}
+
+// Rules configures XI selection for an auction. A MaxOverseas of 0 means no
+// overseas cap.
+type Rules struct {
+	Size          int       `bson:"size" json:"size"`
+	Batters       RoleLimit `bson:"batters" json:"batters"`
+	Bowlers       RoleLimit `bson:"bowlers" json:"bowlers"`
+	AllRounders   RoleLimit `bson:"all_rounders" json:"all_rounders"`
+	WicketKeepers RoleLimit `bson:"wicket_keepers" json:"wicket_keepers"`
+	MaxOverseas   int       `bson:"max_overseas" json:"max_overseas"`
+}
+
+// DefaultRules are the IPL squad rules: an XI of 11 with at least one
+// wicket-keeper and at most four overseas players.
+func DefaultRules() Rules {
+	return Rules{
+		Size:          11,
+		WicketKeepers: RoleLimit{Min: 1},
+		MaxOverseas:   4,
+	}
+}
+
+// ClubRules is an XI of 11 with no role minimums and no overseas cap, for
+// auctions without the IPL's squad rules.
+func ClubRules() Rules {
+	return Rules{Size: 11}
+}
+
+// Check reports a rule set that can never be satisfied.
+func (r Rules) Check() error {
+	if r.Size < 1 {
+		return fmt.Errorf("size must be at least 1")
+	}
+	if r.MaxOverseas < 0 {
+		return fmt.Errorf("max_overseas must be >= 0")
+	}
+	minTotal := 0
+	for _, rl := range r.roles() {
+		if rl.limit.Min < 0 || rl.limit.Max < 0 {
+			return fmt.Errorf("%s limits must be >= 0", rl.role)
+		}
+		if rl.limit.Max > 0 && rl.limit.Min > rl.limit.Max {
+			return fmt.Errorf("%s min is above max", rl.role)
+		}
+		minTotal += rl.limit.Min
+	}
+	if minTotal > r.Size {
+		return fmt.Errorf("role minimums add up to more than %d players", r.Size)
+	}
+	return nil
+}
+
+type roleLimit struct {
+	role  string
+	limit RoleLimit
+}
+
+func (r Rules) roles() []roleLimit {
+	return []roleLimit{
+		{RoleWicketKeeper, r.WicketKeepers},
+		{RoleBatter, r.Batters},
+		{RoleAllRounder, r.AllRounders},
+		{RoleBowler, r.Bowlers},
+	}
+}
+
+// IsOverseas reports whether a player from country is overseas. Players
+// without a country, such as club players, are not.
+func IsOverseas(country string) bool {
+	country = strings.TrimSpace(country)
+	return country != "" && !strings.EqualFold(country, "India")
+}
+
+// Player is what the rules need to know about a squad member.
+type Player struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Overseas bool   `json:"overseas"`
+}
+
+// Violation is one broken rule.
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Counts summarises an XI.
+type Counts struct {
+	Players  int            `json:"players"`
+	Roles    map[string]int `json:"roles"`
+	Overseas int            `json:"overseas"`
+}
+
+// Validate checks the XI (by player ID) against the team's squad and the
+// rules. It returns every violation, not just the first, so a picker can show
+// them all at once.
+func Validate(rules Rules, squad []Player, xi []string) ([]Violation, Counts) {
+	violations := []Violation{}
+	counts := Counts{Roles: map[string]int{
+		RoleWicketKeeper: 0,
+		RoleBatter:       0,
+		RoleAllRounder:   0,
+		RoleBowler:       0,
+	}}
+
+	byID := make(map[string]Player, len(squad))
+	for _, p := range squad {
+		byID[p.ID] = p
+	}
+
+	seen := make(map[string]bool, len(xi))
+	for _, id := range xi {
+		if seen[id] {
+			violations = append(violations, Violation{CodeDuplicate, fmt.Sprintf("Player %s is picked twice", id)})
+			continue
+		}
+		seen[id] = true
+
+		p, ok := byID[id]
+		if !ok {
+			violations = append(violations, Violation{CodeNotInSquad, fmt.Sprintf("Player %s is not in the team's squad", id)})
+			continue
+		}
+		counts.Players++
+		counts.Roles[p.Role]++
+		if p.Overseas {
+			counts.Overseas++
+		}
+	}
+
+	if len(xi) != rules.Size {
+		violations = append(violations, Violation{CodeSize, fmt.Sprintf("Exactly %d players required, got %d", rules.Size, len(xi))})
+	}
+
+	for _, rl := range rules.roles() {
+		n := counts.Roles[rl.role]
+		if n < rl.limit.Min {
+			violations = append(violations, Violation{CodeRoleMin, fmt.Sprintf("At least %d %s required, got %d", rl.limit.Min, plural(rl.role, rl.limit.Min), n)})
+		}
+		if rl.limit.Max > 0 && n > rl.limit.Max {
+			violations = append(violations, Violation{CodeRoleMax, fmt.Sprintf("At most %d %s allowed, got %d", rl.limit.Max, plural(rl.role, rl.limit.Max), n)})
+		}
+	}
+
+	if rules.MaxOverseas > 0 && counts.Overseas > rules.MaxOverseas {
+		violations = append(violations, Violation{CodeMaxOverseas, fmt.Sprintf("At most %d overseas players allowed, got %d", rules.MaxOverseas, counts.Overseas)})
+	}
+
+	return violations, counts
+}
+
+func plural(role string, n int) string {
+	if n == 1 {
+		return role
+	}
+	return role + "s"
+}
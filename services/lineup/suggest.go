@@ -0,0 +1,270 @@
+package lineup
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Projection is a player's expected points for a gameweek.
+type Projection struct {
+	// Average is the player's average points over Matches recent matches.
+	Average float64 `json:"average"`
+	Matches int     `json:"matches"`
+	// Fixtures is how many matches the player's IPL team plays in the
+	// gameweek.
+	Fixtures int     `json:"fixtures"`
+	Points   float64 `json:"points"`
+	Basis    string  `json:"basis"`
+}
+
+// Projection bases.
+const (
+	BasisRecent     = "recent"
+	BasisLastSeason = "last_season"
+	BasisNone       = "none"
+)
+
+// recentWeight is how much more each newer match counts than the one
+// before it when averaging recent form.
+const recentWeight = 1.25
+
+// Project averages a player's recent points, newest first, giving newer
+// matches more weight, and multiplies by the gameweek's fixtures. Without
+// recent matches it falls back to fallbackAverage, e.g. last season's
+// average.
+func Project(recent []int, fallbackAverage float64, fixtures int) Projection {
+	p := Projection{Matches: len(recent), Fixtures: fixtures}
+	switch {
+	case len(recent) > 0:
+		var sum, weights float64
+		w := 1.0
+		for i := len(recent) - 1; i >= 0; i-- {
+			sum += float64(recent[i]) * w
+			weights += w
+			w *= recentWeight
+		}
+		p.Average = sum / weights
+		p.Basis = BasisRecent
+	case fallbackAverage > 0:
+		p.Average = fallbackAverage
+		p.Basis = BasisLastSeason
+	default:
+		p.Basis = BasisNone
+	}
+	p.Points = p.Average * float64(fixtures)
+	return p
+}
+
+// Candidate is a squad player with their projection.
+type Candidate struct {
+	Player
+	Projection Projection
+}
+
+// Pick is the suggestion for one squad player.
+type Pick struct {
+	Player
+	Projection Projection `json:"projection"`
+	Picked     bool       `json:"picked"`
+	Reason     string     `json:"reason"`
+}
+
+// Suggest picks the XI with the highest projected points that the rules
+// allow, found by searching how many players to take from each role, at home
+// and overseas. Role minimums are covered by the best players of each role,
+// then the remaining places go to the best players the rules leave room for.
+// Every squad player gets a reason for being picked or benched. Violations
+// are returned when the squad cannot make a valid XI; the best players that
+// fit are suggested then.
+func Suggest(rules Rules, candidates []Candidate) ([]Pick, []Violation) {
+	order := make([]Candidate, len(candidates))
+	copy(order, candidates)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Projection.Points > order[j].Projection.Points
+	})
+
+	limits := make(map[string]RoleLimit)
+	for _, rl := range rules.roles() {
+		limits[rl.role] = rl.limit
+	}
+
+	// chosen is the best valid XI; without one every player may be picked
+	// as far as the rules allow.
+	chosen, ok := bestXI(rules, order)
+	eligible := func(i int) bool { return !ok || chosen[i] }
+
+	picks := make([]Pick, len(order))
+	roles := make(map[string]int)
+	overseas, picked := 0, 0
+	lowest := 0.0 // lowest projection picked so far
+
+	// benchReason says why c cannot join the XI as it stands, or "" if
+	// they can.
+	benchReason := func(c Candidate) string {
+		if l := limits[c.Role]; l.Max > 0 && roles[c.Role] >= l.Max {
+			return fmt.Sprintf("Benched: already %d %s, the most allowed", l.Max, plural(c.Role, l.Max))
+		}
+		if c.Overseas && rules.MaxOverseas > 0 && overseas >= rules.MaxOverseas {
+			return fmt.Sprintf("Benched: overseas cap of %d reached", rules.MaxOverseas)
+		}
+		if picked >= rules.Size {
+			if c.Projection.Points > lowest {
+				return fmt.Sprintf("Benched: projected %s, but the other places cover the role minimums", describe(c.Projection))
+			}
+			return fmt.Sprintf("Benched: projected %s, below the picked XI", describe(c.Projection))
+		}
+		return ""
+	}
+	pick := func(i int, reason string) {
+		c := order[i]
+		picks[i].Picked = true
+		picks[i].Reason = reason
+		roles[c.Role]++
+		if c.Overseas {
+			overseas++
+		}
+		if picked == 0 || c.Projection.Points < lowest {
+			lowest = c.Projection.Points
+		}
+		picked++
+	}
+	for i, c := range order {
+		picks[i] = Pick{Player: c.Player, Projection: c.Projection}
+	}
+
+	// 1. Role minimums, best players of the role first.
+	for _, rl := range rules.roles() {
+		for i, c := range order {
+			if roles[rl.role] >= rl.limit.Min {
+				break
+			}
+			if c.Role != rl.role || picks[i].Picked || !eligible(i) || benchReason(c) != "" {
+				continue
+			}
+			pick(i, fmt.Sprintf("Picked: best projected %s (%s), covers the minimum of %d", rl.role, describe(c.Projection), rl.limit.Min))
+		}
+	}
+
+	// 2. Remaining places by projection.
+	for i, c := range order {
+		if picks[i].Picked || !eligible(i) || benchReason(c) != "" {
+			continue
+		}
+		pick(i, fmt.Sprintf("Picked: projected %s", describe(c.Projection)))
+	}
+	for i, c := range order {
+		if !picks[i].Picked {
+			picks[i].Reason = benchReason(c)
+		}
+	}
+
+	xi := make([]string, 0, picked)
+	squad := make([]Player, 0, len(picks))
+	for _, p := range picks {
+		squad = append(squad, p.Player)
+		if p.Picked {
+			xi = append(xi, p.ID)
+		}
+	}
+	violations, _ := Validate(rules, squad, xi)
+	return picks, violations
+}
+
+// bestXI finds the valid XI with the highest projected points among order,
+// which is sorted by projection. Within a role, home or overseas, the best
+// players are always the ones to take, so only the number taken from each
+// such group is searched. It reports false when no valid XI exists.
+func bestXI(rules Rules, order []Candidate) ([]bool, bool) {
+	type group struct {
+		role     string
+		overseas bool
+		members  []int     // indexes into order, best first
+		prefix   []float64 // prefix[k] is the points of the best k members
+	}
+	type groupKey struct {
+		role     string
+		overseas bool
+	}
+	var groups []*group
+	byKey := make(map[groupKey]*group)
+	for i, c := range order {
+		key := groupKey{c.Role, c.Overseas}
+		g := byKey[key]
+		if g == nil {
+			g = &group{role: c.Role, overseas: c.Overseas, prefix: []float64{0}}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, i)
+		g.prefix = append(g.prefix, g.prefix[len(g.prefix)-1]+c.Projection.Points)
+	}
+
+	limits := make(map[string]RoleLimit)
+	for _, rl := range rules.roles() {
+		limits[rl.role] = rl.limit
+	}
+
+	take := make([]int, len(groups))
+	var best []int
+	bestPoints := 0.0
+	roles := make(map[string]int)
+
+	var search func(g, left, overseas int, points float64)
+	search = func(g, left, overseas int, points float64) {
+		if g == len(groups) {
+			if left != 0 {
+				return
+			}
+			for _, rl := range rules.roles() {
+				if roles[rl.role] < rl.limit.Min {
+					return
+				}
+			}
+			if best == nil || points > bestPoints {
+				best = append(best[:0], take...)
+				bestPoints = points
+			}
+			return
+		}
+		gr := groups[g]
+		for k := min(left, len(gr.members)); k >= 0; k-- {
+			if l := limits[gr.role]; l.Max > 0 && roles[gr.role]+k > l.Max {
+				continue
+			}
+			o := overseas
+			if gr.overseas {
+				o += k
+				if rules.MaxOverseas > 0 && o > rules.MaxOverseas {
+					continue
+				}
+			}
+			take[g] = k
+			roles[gr.role] += k
+			search(g+1, left-k, o, points+gr.prefix[k])
+			roles[gr.role] -= k
+		}
+	}
+	search(0, rules.Size, 0, 0)
+	if best == nil {
+		return nil, false
+	}
+
+	chosen := make([]bool, len(order))
+	for g, k := range best {
+		for _, i := range groups[g].members[:k] {
+			chosen[i] = true
+		}
+	}
+	return chosen, true
+}
+
+func describe(p Projection) string {
+	switch p.Basis {
+	case BasisRecent:
+		return fmt.Sprintf("%.1f pts (%.1f avg over last %d matches x %d %s)", p.Points, p.Average, p.Matches, p.Fixtures, plural("fixture", p.Fixtures))
+	case BasisLastSeason:
+		return fmt.Sprintf("%.1f pts (%.1f last season avg x %d %s)", p.Points, p.Average, p.Fixtures, plural("fixture", p.Fixtures))
+	default:
+		return "0.0 pts (no match history)"
+	}
+}
@@ -0,0 +1,103 @@
+package lineup
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func candidate(id, role string, overseas bool, points float64) Candidate {
+	return Candidate{
+		Player:     Player{ID: id, Name: id, Role: role, Overseas: overseas},
+		Projection: Projection{Points: points, Basis: BasisRecent, Matches: 1, Fixtures: 1, Average: points},
+	}
+}
+
+func pickedIDs(picks []Pick) string {
+	var ids []string
+	for _, p := range picks {
+		if p.Picked {
+			ids = append(ids, p.ID)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      Rules
+		squad      []Candidate
+		want       string // picked IDs, sorted
+		violations int
+	}{
+		{
+			// Taking the overseas keeper first leaves only an overseas
+			// bowler for the bowler minimum, over the cap.
+			name: "overseas cap across role minimums",
+			rules: Rules{
+				Size:          4,
+				WicketKeepers: RoleLimit{Min: 1},
+				Bowlers:       RoleLimit{Min: 1},
+				MaxOverseas:   1,
+			},
+			squad: []Candidate{
+				candidate("wk-os", RoleWicketKeeper, true, 100),
+				candidate("wk", RoleWicketKeeper, false, 10),
+				candidate("bowl-os", RoleBowler, true, 90),
+				candidate("bat1", RoleBatter, false, 50),
+				candidate("bat2", RoleBatter, false, 40),
+				candidate("bat3", RoleBatter, false, 30),
+			},
+			want: "bat1,bat2,bowl-os,wk",
+		},
+		{
+			// The best keeper would use the overseas place the far better
+			// overseas batter needs.
+			name: "best total, not best first",
+			rules: Rules{
+				Size:          2,
+				WicketKeepers: RoleLimit{Min: 1, Max: 1},
+				MaxOverseas:   1,
+			},
+			squad: []Candidate{
+				candidate("wk-os", RoleWicketKeeper, true, 100),
+				candidate("bat-os", RoleBatter, true, 95),
+				candidate("wk", RoleWicketKeeper, false, 90),
+				candidate("bat", RoleBatter, false, 0),
+			},
+			want: "bat-os,wk",
+		},
+		{
+			name: "no valid XI",
+			rules: Rules{
+				Size:          3,
+				WicketKeepers: RoleLimit{Min: 1},
+			},
+			squad: []Candidate{
+				candidate("bat1", RoleBatter, false, 80),
+				candidate("bat2", RoleBatter, false, 70),
+				candidate("bowl", RoleBowler, false, 20),
+			},
+			want:       "bat1,bat2,bowl",
+			violations: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			picks, violations := Suggest(tt.rules, tt.squad)
+			if got := pickedIDs(picks); got != tt.want {
+				t.Errorf("picked %s, want %s", got, tt.want)
+			}
+			if len(violations) != tt.violations {
+				t.Errorf("got violations %v, want %d", violations, tt.violations)
+			}
+			for _, p := range picks {
+				if p.Reason == "" {
+					t.Errorf("%s has no reason", p.ID)
+				}
+			}
+		})
+	}
+}
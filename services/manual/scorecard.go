@@ -0,0 +1,345 @@
+// Package manual builds scorecards entered by hand, for matches no score
+// provider covers, in the shape the Cricbuzz scorecard has so they are
+// scored by the same pipeline.
+package manual
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProviderName identifies manually entered scorecards. Their match IDs are
+// numbered per database, starting at 1, and each belongs to one auction.
+const ProviderName = "manual"
+
+// Scorecard is a hand-entered scorecard.
+type Scorecard struct {
+	MatchDesc string    `json:"match_desc"`
+	Format    string    `json:"format"`
+	Status    string    `json:"status"` // result text, e.g. "Lions won by 12 runs" or "No result"
+	Innings   []Innings `json:"innings"`
+}
+
+// Innings is one innings of a hand-entered scorecard. Dismissals are written
+// as on a printed scorecard, e.g. "c Sharma b Khan", and name the bowlers
+// of the innings.
+type Innings struct {
+	Team      string    `json:"team"`
+	TeamShort string    `json:"team_short"`
+	Extras    int       `json:"extras"`
+	Batting   []Batting `json:"batting"`
+	Bowling   []Bowling `json:"bowling"`
+}
+
+// Batting is a batsman's innings. With a player ID the auction player's
+// name is used.
+type Batting struct {
+	PlayerID  primitive.ObjectID `json:"player_id"`
+	Name      string             `json:"name"`
+	Runs      int                `json:"runs"`
+	Balls     int                `json:"balls"`
+	Fours     int                `json:"fours"`
+	Sixes     int                `json:"sixes"`
+	Dismissal string             `json:"dismissal"`
+	Captain   bool               `json:"captain"`
+	Keeper    bool               `json:"keeper"`
+}
+
+// Bowling is a bowler's spell. Wickets are counted from the dismissals.
+type Bowling struct {
+	PlayerID primitive.ObjectID `json:"player_id"`
+	Name     string             `json:"name"`
+	Overs    string             `json:"overs"` // 12.4 notation
+	Maidens  int                `json:"maidens"`
+	Runs     int                `json:"runs"`
+	Dots     int                `json:"dots"`
+}
+
+// Resolve replaces the names of entries with a player ID by the names of
+// those auction players.
+func Resolve(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, sc *Scorecard) error {
+	ids := []primitive.ObjectID{}
+	for _, inn := range sc.Innings {
+		for _, b := range inn.Batting {
+			if !b.PlayerID.IsZero() {
+				ids = append(ids, b.PlayerID)
+			}
+		}
+		for _, b := range inn.Bowling {
+			if !b.PlayerID.IsZero() {
+				ids = append(ids, b.PlayerID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "auction_id": auctionID},
+		options.Find().SetProjection(bson.M{"player_name": 1}),
+	)
+	if err != nil {
+		return err
+	}
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return err
+	}
+	names := make(map[primitive.ObjectID]string, len(players))
+	for _, p := range players {
+		names[p.Id] = p.PlayerName
+	}
+
+	name := func(id primitive.ObjectID, current *string) error {
+		if id.IsZero() {
+			return nil
+		}
+		n, ok := names[id]
+		if !ok {
+			return &Error{fmt.Sprintf("player %s is not in this auction", id.Hex())}
+		}
+		*current = n
+		return nil
+	}
+	for i := range sc.Innings {
+		inn := &sc.Innings[i]
+		for j := range inn.Batting {
+			if err := name(inn.Batting[j].PlayerID, &inn.Batting[j].Name); err != nil {
+				return err
+			}
+		}
+		for j := range inn.Bowling {
+			if err := name(inn.Bowling[j].PlayerID, &inn.Bowling[j].Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Error is a problem with the entered scorecard.
+type Error struct {
+	msg string
+}
+
+func (e *Error) Error() string { return e.msg }
+
+func invalid(format string, args ...any) error {
+	return &Error{fmt.Sprintf(format, args...)}
+}
+
+// Build checks a hand-entered scorecard and converts it to the Cricbuzz
+// shape. Innings totals, wickets and overs are worked out from the batting
+// and bowling entries.
+func Build(matchID int, sc Scorecard) (*cricbuzz.ScorecardResponse, error) {
+	if len(sc.Innings) == 0 {
+		return nil, invalid("at least one innings is required")
+	}
+	if sc.Format != "" && fantasy.NormalizeFormat(sc.Format) == "" {
+		return nil, invalid("format must be T20, ODI or TEST")
+	}
+
+	resp := &cricbuzz.ScorecardResponse{
+		Scorecard:       make([]cricbuzz.ScorecardInnings, 0, len(sc.Innings)),
+		IsMatchComplete: true,
+		Status:          sc.Status,
+		MatchHeader: &cricbuzz.MatchDetail{
+			MatchID:     matchID,
+			MatchDesc:   sc.MatchDesc,
+			MatchFormat: sc.Format,
+			State:       "Complete",
+			Status:      sc.Status,
+		},
+	}
+
+	for i, in := range sc.Innings {
+		n := i + 1
+		if strings.TrimSpace(in.Team) == "" {
+			return nil, invalid("innings %d: team is required", n)
+		}
+		if in.Extras < 0 {
+			return nil, invalid("innings %d: extras must be >= 0", n)
+		}
+		short := in.TeamShort
+		if short == "" {
+			short = in.Team
+		}
+		inn := cricbuzz.ScorecardInnings{
+			InningsID:    n,
+			Batsmen:      make([]cricbuzz.Batsman, 0, len(in.Batting)),
+			Bowlers:      make([]cricbuzz.Bowler, 0, len(in.Bowling)),
+			Score:        in.Extras,
+			BatTeamName:  in.Team,
+			BatTeamSName: short,
+		}
+
+		balls := 0
+		bowlers := make(map[string]bool, len(in.Bowling))
+		for _, b := range in.Bowling {
+			name := strings.TrimSpace(b.Name)
+			if name == "" {
+				return nil, invalid("innings %d: every bowler needs a name or player_id", n)
+			}
+			bb, ok := parseOvers(b.Overs)
+			if !ok {
+				return nil, invalid("innings %d: %s: invalid overs %q", n, name, b.Overs)
+			}
+			if b.Maidens < 0 || b.Runs < 0 || b.Dots < 0 || b.Dots > bb {
+				return nil, invalid("innings %d: %s: invalid bowling figures", n, name)
+			}
+			balls += bb
+			bowlers[strings.ToLower(name)] = true
+			inn.Bowlers = append(inn.Bowlers, cricbuzz.Bowler{
+				Name:    name,
+				Overs:   b.Overs,
+				Maidens: b.Maidens,
+				Runs:    b.Runs,
+				Economy: economy(b.Runs, bb),
+				Dots:    b.Dots,
+				Balls:   bb,
+			})
+		}
+		inn.Overs = float64(balls/6) + float64(balls%6)/10
+
+		wickets := make(map[string]int)
+		for _, b := range in.Batting {
+			name := strings.TrimSpace(b.Name)
+			if name == "" {
+				return nil, invalid("innings %d: every batsman needs a name or player_id", n)
+			}
+			if b.Runs < 0 || b.Balls < 0 || b.Fours < 0 || b.Sixes < 0 || 4*b.Fours+6*b.Sixes > b.Runs {
+				return nil, invalid("innings %d: %s: invalid batting figures", n, name)
+			}
+			d := fantasy.ParseDismissal(b.Dismissal)
+			if d.Type == fantasy.Other {
+				return nil, invalid("innings %d: %s: unrecognised dismissal %q", n, name, b.Dismissal)
+			}
+			if d.Bowler != "" {
+				if !bowlers[strings.ToLower(d.Bowler)] {
+					return nil, invalid("innings %d: %s: bowler %q is not among the innings' bowlers", n, name, d.Bowler)
+				}
+				if d.Type.CreditsBowler() {
+					wickets[strings.ToLower(d.Bowler)]++
+				}
+			}
+			if d.Type.IsOut() {
+				inn.Wickets++
+			}
+			inn.Score += b.Runs
+			inn.Batsmen = append(inn.Batsmen, cricbuzz.Batsman{
+				Name:       name,
+				Runs:       b.Runs,
+				Balls:      b.Balls,
+				Fours:      b.Fours,
+				Sixes:      b.Sixes,
+				StrikeRate: strikeRate(b.Runs, b.Balls),
+				OutDesc:    d.RawText,
+				IsCaptain:  b.Captain,
+				IsKeeper:   b.Keeper,
+			})
+		}
+		for j := range inn.Bowlers {
+			inn.Bowlers[j].Wickets = wickets[strings.ToLower(inn.Bowlers[j].Name)]
+		}
+		if inn.Wickets > 10 {
+			return nil, invalid("innings %d: more than 10 wickets", n)
+		}
+
+		resp.Scorecard = append(resp.Scorecard, inn)
+	}
+
+	if len(resp.Scorecard) >= 2 {
+		resp.MatchHeader.Team1 = cricbuzz.TeamInfo{TeamName: resp.Scorecard[0].BatTeamName, TeamSName: resp.Scorecard[0].BatTeamSName}
+		resp.MatchHeader.Team2 = cricbuzz.TeamInfo{TeamName: resp.Scorecard[1].BatTeamName, TeamSName: resp.Scorecard[1].BatTeamSName}
+	}
+	return resp, nil
+}
+
+// matchIDCounter is the counters document that numbers manual matches.
+const matchIDCounter = "manual_match_id"
+
+// NextMatchID allocates the next manual match ID. IDs come from an atomic
+// counter, so concurrent callers never get the same one. The counter starts
+// from the highest ID already stored.
+func NextMatchID(ctx context.Context, db *mongo.Database) (int, error) {
+	counters := db.Collection(constants.CounterCollection)
+
+	n, err := counters.CountDocuments(ctx, bson.M{"_id": matchIDCounter})
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		var last models.Scorecard
+		err := db.Collection(constants.ScorecardCollection).FindOne(ctx,
+			bson.M{"provider": ProviderName},
+			options.FindOne().
+				SetSort(bson.D{{Key: "provider_match_id", Value: -1}}).
+				SetProjection(bson.M{"provider_match_id": 1}),
+		).Decode(&last)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return 0, err
+		}
+		// Another caller may have seeded it first; its value is as good.
+		_, err = counters.InsertOne(ctx, bson.M{"_id": matchIDCounter, "seq": last.ProviderMatchID})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return 0, err
+		}
+	}
+
+	var counter struct {
+		Seq int `bson:"seq"`
+	}
+	err = counters.FindOneAndUpdate(ctx,
+		bson.M{"_id": matchIDCounter},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Seq, nil
+}
+
+// parseOvers converts overs in the 12.4 notation to balls.
+func parseOvers(s string) (int, bool) {
+	whole, part, found := strings.Cut(strings.TrimSpace(s), ".")
+	o, err := strconv.Atoi(whole)
+	if err != nil || o < 0 {
+		return 0, false
+	}
+	b := 0
+	if found {
+		b, err = strconv.Atoi(part)
+		if err != nil || b < 0 || b > 5 || len(part) != 1 {
+			return 0, false
+		}
+	}
+	return o*6 + b, true
+}
+
+func economy(runs, balls int) string {
+	if balls == 0 {
+		return "0.0"
+	}
+	return strconv.FormatFloat(float64(runs)*6/float64(balls), 'f', 1, 64)
+}
+
+func strikeRate(runs, balls int) string {
+	if balls == 0 {
+		return "0.00"
+	}
+	return strconv.FormatFloat(float64(runs)*100/float64(balls), 'f', 2, 64)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cric-auction-monolith/services/cricbuzz"
+)
+
+// File names used by FileProvider and Recorder inside their directory.
+const (
+	recentMatchesFile = "recent_matches.json"
+	scorecardFile     = "scorecard_%d.json"
+	seriesFile        = "series_%d.json"
+)
+
+// FileProvider serves recorded Cricbuzz JSON responses from a directory:
+//
+//	recent_matches.json      response of /matches/recent
+//	scorecard_<matchID>.json response of /match/<matchID>/scorecard
+//	series_<seriesID>.json   response of /series/<seriesID>/matches
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+// Name returns "cricbuzz" because the recordings keep Cricbuzz match IDs.
+func (p *FileProvider) Name() string {
+	return cricbuzz.ProviderName
+}
+
+// FetchRecentMatches reads recent_matches.json.
+func (p *FileProvider) FetchRecentMatches(ctx context.Context) (*cricbuzz.RecentMatchesResponse, error) {
+	var result cricbuzz.RecentMatchesResponse
+	if err := p.read(recentMatchesFile, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchScorecard reads scorecard_<matchID>.json.
+func (p *FileProvider) FetchScorecard(ctx context.Context, matchID int) (*cricbuzz.ScorecardResponse, error) {
+	var result cricbuzz.ScorecardResponse
+	if err := p.read(fmt.Sprintf(scorecardFile, matchID), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchSeriesMatches reads series_<seriesID>.json.
+func (p *FileProvider) FetchSeriesMatches(ctx context.Context, seriesID int) (*cricbuzz.SeriesMatchesResponse, error) {
+	var result cricbuzz.SeriesMatchesResponse
+	if err := p.read(fmt.Sprintf(seriesFile, seriesID), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (p *FileProvider) read(name string, v any) error {
+	body, err := os.ReadFile(filepath.Join(p.dir, name))
+	if err != nil {
+		return fmt.Errorf("read fixture: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decode fixture %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cric-auction-monolith/services/fantasy"
+
+	"go.uber.org/zap"
+)
+
+// testdata/scorecard_101.json is a recorded T20 scorecard, trimmed to a few
+// players per innings.
+const recordedMatchID = 101
+
+func TestFileProviderReplaysRecordedMatch(t *testing.T) {
+	scorecard, err := NewFileProvider("testdata").FetchScorecard(context.Background(), recordedMatchID)
+	if err != nil {
+		t.Fatalf("FetchScorecard: %v", err)
+	}
+
+	want := map[string]int{
+		"Rohit Sharma":     106, // 52 runs, 6x4, 2x6, fifty, SR 173
+		"Ishan Kishan":     14,  // duck, stumping
+		"Suryakumar Yadav": 69,
+		"Tim David":        12,
+		"Ruturaj Gaikwad":  73,
+		"MS Dhoni":         52,
+		"Ravindra Jadeja":  74, // bats, bowls and a direct-hit run out
+		"Deepak Chahar":    52,
+		"Jasprit Bumrah":   104, // 2 wickets, maiden, 14 dots, economy 4.5
+		"Piyush Chawla":    38,
+	}
+
+	got := fantasy.CalculateAllPoints(scorecard)
+	if len(got) != len(want) {
+		t.Errorf("scored %d players, want %d", len(got), len(want))
+	}
+	for name, points := range want {
+		pp, ok := got[name]
+		if !ok {
+			t.Errorf("%s: not scored", name)
+			continue
+		}
+		if pp.Points != points {
+			t.Errorf("%s: got %d points, want %d (%v)", name, pp.Points, points, pp.Breakdown.Details)
+		}
+	}
+}
+
+func TestRecorderWritesFileProviderLayout(t *testing.T) {
+	dir := t.TempDir()
+	rec := NewRecorder(zap.NewNop(), NewFileProvider("testdata"), dir)
+
+	want, err := rec.FetchScorecard(context.Background(), recordedMatchID)
+	if err != nil {
+		t.Fatalf("FetchScorecard: %v", err)
+	}
+
+	got, err := NewFileProvider(dir).FetchScorecard(context.Background(), recordedMatchID)
+	if err != nil {
+		t.Fatalf("replay recording: %v", err)
+	}
+	if len(got.Scorecard) != len(want.Scorecard) || got.Status != want.Status {
+		t.Errorf("replayed %d innings %q, want %d innings %q",
+			len(got.Scorecard), got.Status, len(want.Scorecard), want.Status)
+	}
+}
+
+func TestRecorderReturnsResultWhenWriteFails(t *testing.T) {
+	// A regular file where the recording directory should be.
+	dir := filepath.Join(t.TempDir(), "recordings")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rec := NewRecorder(zap.NewNop(), NewFileProvider("testdata"), dir)
+
+	result, err := rec.FetchScorecard(context.Background(), recordedMatchID)
+	if err != nil {
+		t.Fatalf("FetchScorecard: %v", err)
+	}
+	if result == nil || len(result.Scorecard) == 0 {
+		t.Fatal("FetchScorecard returned no scorecard")
+	}
+}
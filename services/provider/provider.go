@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"cric-auction-monolith/services/cricbuzz"
+
+	"go.uber.org/zap"
+)
+
+// ScoreProvider supplies recent matches and scorecards to the points pipeline.
+type ScoreProvider interface {
+	// Name identifies where match IDs come from, e.g. "cricbuzz".
+	Name() string
+	FetchRecentMatches(ctx context.Context) (*cricbuzz.RecentMatchesResponse, error)
+	FetchScorecard(ctx context.Context, matchID int) (*cricbuzz.ScorecardResponse, error)
+	FetchSeriesMatches(ctx context.Context, seriesID int) (*cricbuzz.SeriesMatchesResponse, error)
+}
+
+// QuotaOf returns the API quota of p when it, or the provider it wraps, is
+// backed by the Cricbuzz client.
+func QuotaOf(p ScoreProvider) (cricbuzz.Quota, bool) {
+	for {
+		switch v := p.(type) {
+		case *cricbuzz.Client:
+			return v.Quota(), true
+		case *Recorder:
+			p = v.next
+		default:
+			return cricbuzz.Quota{}, false
+		}
+	}
+}
+
+// NewFromEnv builds the provider selected by SCORE_PROVIDER:
+//   - "cricbuzz" (default): the RapidAPI Cricbuzz client
+//   - "file": recorded JSON from SCORE_FIXTURES_DIR
+//
+// When SCORE_RECORD_DIR is set, every response is also written there in the
+// layout the file provider reads, so live matches can be replayed offline.
+func NewFromEnv(logger *zap.Logger) (ScoreProvider, error) {
+	var p ScoreProvider
+
+	switch kind := os.Getenv("SCORE_PROVIDER"); kind {
+	case "", "cricbuzz":
+		p = cricbuzz.NewClient(
+			os.Getenv("CRICBUZZ_API_KEY"),
+			os.Getenv("CRICBUZZ_API_HOST"),
+		)
+	case "file":
+		dir := os.Getenv("SCORE_FIXTURES_DIR")
+		if dir == "" {
+			return nil, fmt.Errorf("SCORE_FIXTURES_DIR is required for the file score provider")
+		}
+		p = NewFileProvider(dir)
+	default:
+		return nil, fmt.Errorf("unknown score provider %q", kind)
+	}
+
+	if dir := os.Getenv("SCORE_RECORD_DIR"); dir != "" {
+		p = NewRecorder(logger, p, dir)
+	}
+	return p, nil
+}
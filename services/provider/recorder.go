@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cric-auction-monolith/services/cricbuzz"
+
+	"go.uber.org/zap"
+)
+
+// Recorder wraps a provider and writes every successful response to a
+// directory in the FileProvider layout. A recording that cannot be written
+// is logged; the response is still returned to the caller.
+type Recorder struct {
+	logger *zap.Logger
+	next   ScoreProvider
+	dir    string
+}
+
+func NewRecorder(logger *zap.Logger, next ScoreProvider, dir string) *Recorder {
+	return &Recorder{logger: logger, next: next, dir: dir}
+}
+
+func (r *Recorder) Name() string {
+	return r.next.Name()
+}
+
+func (r *Recorder) FetchRecentMatches(ctx context.Context) (*cricbuzz.RecentMatchesResponse, error) {
+	result, err := r.next.FetchRecentMatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.record(recentMatchesFile, result)
+	return result, nil
+}
+
+func (r *Recorder) FetchScorecard(ctx context.Context, matchID int) (*cricbuzz.ScorecardResponse, error) {
+	result, err := r.next.FetchScorecard(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	r.record(fmt.Sprintf(scorecardFile, matchID), result)
+	return result, nil
+}
+
+func (r *Recorder) FetchSeriesMatches(ctx context.Context, seriesID int) (*cricbuzz.SeriesMatchesResponse, error) {
+	result, err := r.next.FetchSeriesMatches(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	r.record(fmt.Sprintf(seriesFile, seriesID), result)
+	return result, nil
+}
+
+func (r *Recorder) record(name string, v any) {
+	if err := r.write(name, v); err != nil {
+		r.logger.Warn("failed to record provider response", zap.String("file", name), zap.Error(err))
+	}
+}
+
+func (r *Recorder) write(name string, v any) error {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode recording: %w", err)
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("create recording dir: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, name), body, 0o644); err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,597 @@
+// Package scoring scores club matches ball by ball. A match is a log of
+// events (batters coming in, bowler changes, deliveries) that State replays
+// into the batting and bowling cards, one event at a time.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// What the scorer has to enter next, reported in State.Next.
+const (
+	NextBatter   = "batter"
+	NextBowler   = "bowler"
+	NextBall     = "ball"
+	NextFinished = "finished"
+)
+
+// Player is a squad player who can take part in the match.
+type Player struct {
+	ID   primitive.ObjectID
+	Name string
+}
+
+// Side is one team of the match.
+type Side struct {
+	TeamID  primitive.ObjectID
+	Name    string
+	Players []Player
+}
+
+// Match is what a live match is played with. BattingFirst is the index in
+// Sides of the team batting first; Overs is the overs per innings.
+type Match struct {
+	Sides        [2]Side
+	BattingFirst int
+	Overs        int
+}
+
+// State is a match after the events applied so far.
+type State struct {
+	Innings  []*Innings `json:"innings"`
+	Complete bool       `json:"complete"`
+	Result   string     `json:"result,omitempty"`
+	Next     string     `json:"next"`
+
+	match  Match
+	names  map[primitive.ObjectID]string
+	sideOf map[primitive.ObjectID]int
+}
+
+// Innings is the running card of one innings.
+type Innings struct {
+	Team       string             `json:"team"`
+	TeamID     primitive.ObjectID `json:"team_id"`
+	Runs       int                `json:"runs"`
+	Wickets    int                `json:"wickets"`
+	Overs      string             `json:"overs"`
+	Extras     int                `json:"extras"`
+	Target     int                `json:"target,omitempty"`
+	Batting    []*BatCard         `json:"batting"`
+	Bowling    []*BowlCard        `json:"bowling"`
+	Striker    primitive.ObjectID `json:"striker,omitempty"`
+	NonStriker primitive.ObjectID `json:"non_striker,omitempty"`
+	Bowler     primitive.ObjectID `json:"bowler,omitempty"`
+	Closed     bool               `json:"closed"`
+	AllOut     bool               `json:"all_out"`
+
+	side           int
+	balls          int
+	overBalls      int
+	overConceded   int
+	overChanged    bool // the bowler was replaced during the over
+	lastOverBowler primitive.ObjectID
+}
+
+// BatCard is a batter's innings.
+type BatCard struct {
+	PlayerID  primitive.ObjectID `json:"player_id"`
+	Name      string             `json:"name"`
+	Runs      int                `json:"runs"`
+	Balls     int                `json:"balls"`
+	Fours     int                `json:"fours"`
+	Sixes     int                `json:"sixes"`
+	Dismissal string             `json:"dismissal"`
+	Out       bool               `json:"out"`
+}
+
+// BowlCard is a bowler's figures.
+type BowlCard struct {
+	PlayerID primitive.ObjectID `json:"player_id"`
+	Name     string             `json:"name"`
+	Overs    string             `json:"overs"`
+	Maidens  int                `json:"maidens"`
+	Runs     int                `json:"runs"`
+	Wickets  int                `json:"wickets"`
+	Dots     int                `json:"dots"`
+
+	balls int
+}
+
+// New starts a match with no events.
+func New(m Match) *State {
+	s := &State{
+		Innings: []*Innings{},
+		match:   m,
+		names:   make(map[primitive.ObjectID]string),
+		sideOf:  make(map[primitive.ObjectID]int),
+	}
+	for i, side := range m.Sides {
+		for _, p := range side.Players {
+			s.names[p.ID] = p.Name
+			s.sideOf[p.ID] = i
+		}
+	}
+	s.startInnings(m.BattingFirst, 0)
+	return s
+}
+
+// Replay applies events in order to a new match.
+func Replay(m Match, events []models.LiveEvent) (*State, error) {
+	s := New(m)
+	for i, e := range events {
+		if err := s.Apply(e); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i+1, err)
+		}
+	}
+	return s, nil
+}
+
+// Undo drops the last ball of the log together with the events recorded
+// after it, such as the next batter or bowler.
+func Undo(events []models.LiveEvent) ([]models.LiveEvent, error) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Kind == models.LiveEventBall {
+			return events[:i], nil
+		}
+	}
+	return nil, errors.New("no ball to undo")
+}
+
+func (s *State) current() *Innings {
+	return s.Innings[len(s.Innings)-1]
+}
+
+func (s *State) startInnings(side, target int) {
+	s.Innings = append(s.Innings, &Innings{
+		Team:    s.match.Sides[side].Name,
+		TeamID:  s.match.Sides[side].TeamID,
+		Overs:   "0.0",
+		Target:  target,
+		Batting: []*BatCard{},
+		Bowling: []*BowlCard{},
+		side:    side,
+	})
+	s.Next = NextBatter
+}
+
+// Apply records one event.
+func (s *State) Apply(e models.LiveEvent) error {
+	if s.Complete {
+		return errors.New("the match is over")
+	}
+	inn := s.current()
+
+	switch e.Kind {
+	case models.LiveEventBatter:
+		if err := s.batter(inn, e.PlayerID); err != nil {
+			return err
+		}
+	case models.LiveEventBowler:
+		if err := s.bowler(inn, e.PlayerID); err != nil {
+			return err
+		}
+	case models.LiveEventBall:
+		if err := s.ball(inn, e); err != nil {
+			return err
+		}
+	case models.LiveEventRetire:
+		if err := s.retire(inn, e); err != nil {
+			return err
+		}
+	case models.LiveEventEndInnings:
+		s.close(inn)
+	default:
+		return fmt.Errorf("unknown event kind %q", e.Kind)
+	}
+
+	s.Next = s.next()
+	return nil
+}
+
+func (s *State) next() string {
+	if s.Complete {
+		return NextFinished
+	}
+	inn := s.current()
+	switch {
+	case inn.Striker.IsZero() || inn.NonStriker.IsZero():
+		return NextBatter
+	case inn.Bowler.IsZero():
+		return NextBowler
+	}
+	return NextBall
+}
+
+func (s *State) batter(inn *Innings, id primitive.ObjectID) error {
+	if side, ok := s.sideOf[id]; !ok || side != inn.side {
+		return errors.New("the batter is not in the batting side")
+	}
+	if !inn.Striker.IsZero() && !inn.NonStriker.IsZero() {
+		return errors.New("both batters are in")
+	}
+
+	card := inn.batCard(id)
+	switch {
+	case card == nil:
+		inn.Batting = append(inn.Batting, &BatCard{PlayerID: id, Name: s.names[id]})
+	case card.Dismissal == dismissalText(fantasy.RetiredHurt, "", "", "") && id != inn.Striker && id != inn.NonStriker:
+		// A retired hurt batter resumes the innings.
+		card.Dismissal = ""
+	default:
+		return errors.New("the batter has already batted")
+	}
+
+	if inn.Striker.IsZero() {
+		inn.Striker = id
+	} else {
+		inn.NonStriker = id
+	}
+	return nil
+}
+
+func (s *State) bowler(inn *Innings, id primitive.ObjectID) error {
+	if side, ok := s.sideOf[id]; !ok || side == inn.side {
+		return errors.New("the bowler is not in the fielding side")
+	}
+	if id == inn.lastOverBowler {
+		return errors.New("a bowler cannot bowl two overs in a row")
+	}
+	if id == inn.Bowler {
+		return nil
+	}
+	if !inn.Bowler.IsZero() && inn.overBalls > 0 {
+		inn.overChanged = true
+	}
+	if inn.bowlCard(id) == nil {
+		inn.Bowling = append(inn.Bowling, &BowlCard{PlayerID: id, Name: s.names[id], Overs: "0.0"})
+	}
+	inn.Bowler = id
+	return nil
+}
+
+func (s *State) ball(inn *Innings, e models.LiveEvent) error {
+	if inn.Striker.IsZero() || inn.NonStriker.IsZero() {
+		return errors.New("both batters must be in before a ball")
+	}
+	if inn.Bowler.IsZero() {
+		return errors.New("a bowler must be chosen before a ball")
+	}
+	if e.Runs < 0 || e.ExtraRuns < 0 || e.Runs > 7 || e.ExtraRuns > 7 {
+		return errors.New("runs must be between 0 and 7")
+	}
+	if e.Boundary && e.Runs != 4 {
+		return errors.New("a boundary scores four runs off the bat")
+	}
+
+	penalty := 0
+	switch e.Extra {
+	case "":
+	case models.ExtraWide:
+		penalty = 1
+		if e.Runs > 0 {
+			return errors.New("no runs off the bat on a wide")
+		}
+	case models.ExtraNoBall:
+		penalty = 1
+	case models.ExtraBye, models.ExtraLegBye:
+		if e.Runs > 0 {
+			return errors.New("no runs off the bat on a bye or leg bye")
+		}
+	default:
+		return fmt.Errorf("unknown extra %q", e.Extra)
+	}
+	legal := e.Extra != models.ExtraWide && e.Extra != models.ExtraNoBall
+
+	var out *BatCard
+	var text string
+	if e.Wicket != nil {
+		var err error
+		if out, text, err = s.wicket(inn, e); err != nil {
+			return err
+		}
+	}
+
+	// Byes and leg byes, also off a no-ball, are not the bowler's runs;
+	// runs run on a wide are.
+	conceded := e.Runs + penalty
+	if e.Extra == models.ExtraWide {
+		conceded += e.ExtraRuns
+	}
+	inn.Runs += e.Runs + e.ExtraRuns + penalty
+	inn.Extras += e.ExtraRuns + penalty
+
+	striker := inn.batCard(inn.Striker)
+	if e.Extra != models.ExtraWide {
+		striker.Balls++
+	}
+	striker.Runs += e.Runs
+	if e.Runs == 4 && e.Boundary {
+		striker.Fours++
+	}
+	if e.Runs == 6 {
+		striker.Sixes++
+	}
+
+	bowler := inn.bowlCard(inn.Bowler)
+	bowler.Runs += conceded
+	inn.overConceded += conceded
+	if legal {
+		bowler.balls++
+		bowler.Overs = overs(bowler.balls)
+		if conceded == 0 {
+			bowler.Dots++
+		}
+	}
+
+	if (e.Runs+e.ExtraRuns)%2 == 1 {
+		inn.Striker, inn.NonStriker = inn.NonStriker, inn.Striker
+	}
+
+	if out != nil {
+		out.Dismissal = text
+		out.Out = true
+		inn.Wickets++
+		if fantasy.ParseDismissal(text).Type.CreditsBowler() {
+			bowler.Wickets++
+		}
+		if inn.Striker == out.PlayerID {
+			inn.Striker = primitive.NilObjectID
+		} else {
+			inn.NonStriker = primitive.NilObjectID
+		}
+	}
+
+	if legal {
+		inn.balls++
+		inn.overBalls++
+		inn.Overs = overs(inn.balls)
+		if inn.overBalls == 6 {
+			if inn.overConceded == 0 && !inn.overChanged {
+				bowler.Maidens++
+			}
+			inn.Striker, inn.NonStriker = inn.NonStriker, inn.Striker
+			inn.lastOverBowler = inn.Bowler
+			inn.Bowler = primitive.NilObjectID
+			inn.overBalls, inn.overConceded, inn.overChanged = 0, 0, false
+		}
+	}
+
+	s.checkEnd(inn)
+	return nil
+}
+
+// retire records a batter retiring between deliveries. A retired hurt
+// batter is not out and may come back in later.
+func (s *State) retire(inn *Innings, e models.LiveEvent) error {
+	if e.Wicket == nil {
+		return errors.New("retire needs the wicket kind")
+	}
+	kind, ok := dismissalKind(e.Wicket.Kind)
+	if !ok || kind != fantasy.RetiredHurt && kind != fantasy.RetiredOut {
+		return errors.New("a batter retires hurt or out")
+	}
+	id := e.Wicket.PlayerOutID
+	if id.IsZero() || id != inn.Striker && id != inn.NonStriker {
+		return errors.New("the retiring player is not batting")
+	}
+
+	card := inn.batCard(id)
+	card.Dismissal = dismissalText(kind, "", "", "")
+	if kind == fantasy.RetiredOut {
+		card.Out = true
+		inn.Wickets++
+	}
+	if inn.Striker == id {
+		inn.Striker = primitive.NilObjectID
+	} else {
+		inn.NonStriker = primitive.NilObjectID
+	}
+	s.checkEnd(inn)
+	return nil
+}
+
+// checkEnd closes the innings when the side is out, the overs are up or
+// the target is reached.
+func (s *State) checkEnd(inn *Innings) {
+	switch {
+	case inn.Wickets >= s.maxWickets(inn.side):
+		inn.AllOut = true
+		s.close(inn)
+	case s.match.Overs > 0 && inn.balls >= s.match.Overs*6,
+		inn.Target > 0 && inn.Runs >= inn.Target:
+		s.close(inn)
+	}
+}
+
+// Dismissals allowed off a wide or a no-ball; any other needs a fair
+// delivery.
+var (
+	wideDismissals   = []fantasy.DismissalType{fantasy.Stumped, fantasy.HitWicket, fantasy.RunOut, fantasy.ObstructingField, fantasy.HandledBall}
+	noBallDismissals = []fantasy.DismissalType{fantasy.RunOut, fantasy.ObstructingField, fantasy.HandledBall, fantasy.HitBallTwice}
+)
+
+// dismissalNames are the dismissals a scorer can record.
+var dismissalNames = []fantasy.DismissalType{
+	fantasy.Caught, fantasy.CaughtAndBowled, fantasy.Bowled, fantasy.LBW, fantasy.Stumped,
+	fantasy.HitWicket, fantasy.RunOut, fantasy.ObstructingField, fantasy.HandledBall,
+	fantasy.HitBallTwice, fantasy.RetiredHurt, fantasy.RetiredOut,
+}
+
+// dismissalText writes a dismissal as on a printed scorecard.
+func dismissalText(kind fantasy.DismissalType, fielder, fielder2, bowler string) string {
+	switch kind {
+	case fantasy.Caught:
+		return fmt.Sprintf("c %s b %s", fielder, bowler)
+	case fantasy.CaughtAndBowled:
+		return "c & b " + bowler
+	case fantasy.Bowled:
+		return "b " + bowler
+	case fantasy.LBW:
+		return "lbw b " + bowler
+	case fantasy.Stumped:
+		return fmt.Sprintf("st %s b %s", fielder, bowler)
+	case fantasy.HitWicket:
+		return "hit wicket b " + bowler
+	case fantasy.RunOut:
+		switch {
+		case fielder == "":
+			return "run out"
+		case fielder2 == "":
+			return fmt.Sprintf("run out (%s)", fielder)
+		}
+		return fmt.Sprintf("run out (%s/%s)", fielder, fielder2)
+	case fantasy.ObstructingField:
+		return "obstructing the field"
+	case fantasy.HandledBall:
+		return "handled the ball"
+	case fantasy.HitBallTwice:
+		return "hit the ball twice"
+	case fantasy.RetiredHurt:
+		return "retired hurt"
+	case fantasy.RetiredOut:
+		return "retired out"
+	}
+	return ""
+}
+
+// wicket checks the dismissal on a delivery and returns the card of the
+// batter who is out with the scorecard text of the dismissal.
+func (s *State) wicket(inn *Innings, e models.LiveEvent) (*BatCard, string, error) {
+	w := e.Wicket
+	kind, ok := dismissalKind(w.Kind)
+	if !ok || kind == fantasy.RetiredHurt || kind == fantasy.RetiredOut {
+		return nil, "", fmt.Errorf("unknown wicket kind %q", w.Kind)
+	}
+	switch e.Extra {
+	case models.ExtraWide:
+		if !contains(wideDismissals, kind) {
+			return nil, "", fmt.Errorf("%s is not possible off a wide", w.Kind)
+		}
+	case models.ExtraNoBall:
+		if !contains(noBallDismissals, kind) {
+			return nil, "", fmt.Errorf("%s is not possible off a no-ball", w.Kind)
+		}
+	}
+
+	outID := w.PlayerOutID
+	if outID.IsZero() {
+		outID = inn.Striker
+	}
+	switch outID {
+	case inn.Striker:
+	case inn.NonStriker:
+		if kind != fantasy.RunOut && kind != fantasy.ObstructingField {
+			return nil, "", fmt.Errorf("the non-striker cannot be out %s", w.Kind)
+		}
+	default:
+		return nil, "", errors.New("the player out is not batting")
+	}
+
+	for _, id := range []primitive.ObjectID{w.FielderID, w.Fielder2ID} {
+		if side, ok := s.sideOf[id]; !id.IsZero() && (!ok || side == inn.side) {
+			return nil, "", errors.New("the fielder is not in the fielding side")
+		}
+	}
+	if kind == fantasy.Caught && w.FielderID == inn.Bowler {
+		kind = fantasy.CaughtAndBowled
+	}
+	if (kind == fantasy.Caught || kind == fantasy.Stumped) && w.FielderID.IsZero() {
+		return nil, "", fmt.Errorf("%s needs the fielder", w.Kind)
+	}
+
+	if kind == fantasy.RunOut && w.FielderID.IsZero() && !w.Fielder2ID.IsZero() {
+		return nil, "", errors.New("a run out names its first fielder before the second")
+	}
+	text := dismissalText(kind, s.names[w.FielderID], s.names[w.Fielder2ID], s.names[inn.Bowler])
+	return inn.batCard(outID), text, nil
+}
+
+// close ends an innings and starts the chase, or ends the match after it.
+func (s *State) close(inn *Innings) {
+	inn.Closed = true
+	inn.Striker, inn.NonStriker, inn.Bowler = primitive.NilObjectID, primitive.NilObjectID, primitive.NilObjectID
+	if len(s.Innings) == 1 {
+		s.startInnings(1-inn.side, inn.Runs+1)
+		return
+	}
+
+	s.Complete = true
+	first, second := s.Innings[0], s.Innings[1]
+	switch {
+	case second.Runs >= second.Target:
+		wickets := s.maxWickets(second.side) - second.Wickets
+		s.Result = fmt.Sprintf("%s won by %d %s", second.Team, wickets, plural("wicket", wickets))
+	case second.Runs == first.Runs:
+		s.Result = "Match tied"
+	default:
+		runs := first.Runs - second.Runs
+		s.Result = fmt.Sprintf("%s won by %d %s", first.Team, runs, plural("run", runs))
+	}
+}
+
+// maxWickets is how many wickets bowl a side out: one fewer than the
+// batters it has, at most ten.
+func (s *State) maxWickets(side int) int {
+	n := len(s.match.Sides[side].Players)
+	if n > 11 {
+		n = 11
+	}
+	if n < 2 {
+		return 1
+	}
+	return n - 1
+}
+
+func (inn *Innings) batCard(id primitive.ObjectID) *BatCard {
+	for _, c := range inn.Batting {
+		if c.PlayerID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (inn *Innings) bowlCard(id primitive.ObjectID) *BowlCard {
+	for _, c := range inn.Bowling {
+		if c.PlayerID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func dismissalKind(name string) (fantasy.DismissalType, bool) {
+	for _, t := range dismissalNames {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return fantasy.Other, false
+}
+
+func contains(types []fantasy.DismissalType, t fantasy.DismissalType) bool {
+	for _, x := range types {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+func overs(balls int) string {
+	return fmt.Sprintf("%d.%d", balls/6, balls%6)
+}
+
+func plural(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
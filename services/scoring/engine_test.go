@@ -0,0 +1,178 @@
+package scoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testMatch is a one-over match between two sides of three, so two wickets
+// bowl a side out.
+func testMatch() (Match, map[string]primitive.ObjectID) {
+	ids := make(map[string]primitive.ObjectID)
+	side := func(name string, players ...string) Side {
+		s := Side{TeamID: primitive.NewObjectID(), Name: name}
+		for _, p := range players {
+			ids[p] = primitive.NewObjectID()
+			s.Players = append(s.Players, Player{ID: ids[p], Name: p})
+		}
+		return s
+	}
+	return Match{
+		Sides: [2]Side{side("Alpha", "A1", "A2", "A3"), side("Beta", "B1", "B2", "B3")},
+		Overs: 1,
+	}, ids
+}
+
+func batter(id primitive.ObjectID) models.LiveEvent {
+	return models.LiveEvent{Kind: models.LiveEventBatter, PlayerID: id}
+}
+
+func bowler(id primitive.ObjectID) models.LiveEvent {
+	return models.LiveEvent{Kind: models.LiveEventBowler, PlayerID: id}
+}
+
+func ball(runs int) models.LiveEvent {
+	return models.LiveEvent{Kind: models.LiveEventBall, Runs: runs, Boundary: runs == 4}
+}
+
+// fullMatch is a complete match: Alpha make 12/1, Beta chase 13 without
+// losing a wicket.
+func fullMatch(ids map[string]primitive.ObjectID) []models.LiveEvent {
+	return []models.LiveEvent{
+		batter(ids["A1"]), batter(ids["A2"]), bowler(ids["B1"]),
+		ball(4),
+		ball(1),
+		{Kind: models.LiveEventBall, Extra: models.ExtraWide},
+		ball(0),
+		{Kind: models.LiveEventBall, Wicket: &models.LiveWicket{Kind: "bowled"}},
+		batter(ids["A3"]),
+		ball(6),
+		ball(0),
+
+		batter(ids["B1"]), batter(ids["B2"]), bowler(ids["A1"]),
+		ball(6),
+		ball(6),
+		ball(1),
+	}
+}
+
+func TestReplay(t *testing.T) {
+	m, ids := testMatch()
+	s, err := Replay(m, fullMatch(ids))
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if !s.Complete || s.Next != NextFinished || s.Result != "Beta won by 2 wickets" {
+		t.Errorf("got complete %v, next %q, result %q", s.Complete, s.Next, s.Result)
+	}
+
+	first := s.Innings[0]
+	if first.Runs != 12 || first.Wickets != 1 || first.Overs != "1.0" || first.Extras != 1 || !first.Closed {
+		t.Errorf("first innings %d/%d in %s overs, %d extras, closed %v; want 12/1 in 1.0, 1, true",
+			first.Runs, first.Wickets, first.Overs, first.Extras, first.Closed)
+	}
+	if a2 := first.batCard(ids["A2"]); a2.Dismissal != "b B1" || !a2.Out || a2.Balls != 2 {
+		t.Errorf("A2 = %+v, want bowled by B1 off their second ball", *a2)
+	}
+	if a1 := first.batCard(ids["A1"]); a1.Runs != 5 || a1.Balls != 2 || a1.Fours != 1 {
+		t.Errorf("A1 = %+v, want 5 off 2 with a four", *a1)
+	}
+	if b1 := first.bowlCard(ids["B1"]); b1.Runs != 12 || b1.Wickets != 1 || b1.Dots != 3 || b1.Overs != "1.0" {
+		t.Errorf("B1 = %+v, want 1.0-0-12-1 with 3 dots", *b1)
+	}
+
+	second := s.Innings[1]
+	if second.Target != 13 || second.Runs != 13 || second.Overs != "0.3" {
+		t.Errorf("second innings %d in %s overs chasing %d; want 13 in 0.3 chasing 13",
+			second.Runs, second.Overs, second.Target)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	m, ids := testMatch()
+	events := fullMatch(ids)
+
+	tests := []struct {
+		name string
+		log  []models.LiveEvent
+		want []models.LiveEvent // the log once the last ball is undone
+	}{
+		{"last ball of the match", events, events[:16]},
+		{"wicket with the next batter", events[:9], events[:7]},
+		{"wide", events[:6], events[:5]},
+		{"end of innings with the next openers and bowler", events[:14], events[:10]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undone, err := Undo(tt.log)
+			if err != nil {
+				t.Fatalf("Undo: %v", err)
+			}
+			if len(undone) != len(tt.want) {
+				t.Fatalf("Undo kept %d events, want %d", len(undone), len(tt.want))
+			}
+
+			// Replaying the undone log gives the state before that ball.
+			got, err := Replay(m, undone)
+			if err != nil {
+				t.Fatalf("Replay undone: %v", err)
+			}
+			want, err := Replay(m, tt.want)
+			if err != nil {
+				t.Fatalf("Replay want: %v", err)
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("state after undo:\n%s\nwant:\n%s", gotJSON, wantJSON)
+			}
+		})
+	}
+
+	t.Run("match reopens", func(t *testing.T) {
+		undone, _ := Undo(events)
+		s, err := Replay(m, undone)
+		if err != nil {
+			t.Fatalf("Replay: %v", err)
+		}
+		if s.Complete || s.Next != NextBall || s.Innings[1].Runs != 12 {
+			t.Errorf("got complete %v, next %q, %d runs; want an open chase on 12",
+				s.Complete, s.Next, s.Innings[1].Runs)
+		}
+	})
+
+	t.Run("nothing to undo", func(t *testing.T) {
+		if _, err := Undo(events[:3]); err == nil {
+			t.Error("Undo without a ball: want an error")
+		}
+	})
+}
+
+func TestApplyRejects(t *testing.T) {
+	m, ids := testMatch()
+	start := []models.LiveEvent{batter(ids["A1"]), batter(ids["A2"]), bowler(ids["B1"])}
+
+	tests := []struct {
+		name   string
+		events []models.LiveEvent
+	}{
+		{"ball before the batters", []models.LiveEvent{bowler(ids["B1"]), ball(0)}},
+		{"batter from the fielding side", []models.LiveEvent{batter(ids["B1"])}},
+		{"runs off the bat on a wide", append(start, models.LiveEvent{Kind: models.LiveEventBall, Runs: 1, Extra: models.ExtraWide})},
+		{"caught without the fielder", append(start, models.LiveEvent{Kind: models.LiveEventBall, Wicket: &models.LiveWicket{Kind: "caught"}})},
+		{"bowled off a no-ball", append(start, models.LiveEvent{Kind: models.LiveEventBall, Extra: models.ExtraNoBall, Wicket: &models.LiveWicket{Kind: "bowled"}})},
+		{"ball after the match", append(fullMatch(ids), ball(0))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Replay(m, tt.events); err == nil {
+				t.Error("Replay: want an error")
+			}
+		})
+	}
+}
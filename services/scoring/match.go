@@ -0,0 +1,54 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrTeamNotFound is returned when a team of a live match is not a team of
+// its auction.
+var ErrTeamNotFound = errors.New("team not found")
+
+// MatchOf builds the match a live match is played with from the squads of
+// its two auction teams.
+func MatchOf(ctx context.Context, db *mongo.Database, lm *models.LiveMatch) (Match, error) {
+	m := Match{Overs: lm.Overs}
+	for i, id := range []primitive.ObjectID{lm.Team1ID, lm.Team2ID} {
+		var team models.Team
+		err := db.Collection(constants.TeamCollection).FindOne(ctx,
+			bson.M{"_id": id, "auction_id": lm.AuctionID},
+		).Decode(&team)
+		if err == mongo.ErrNoDocuments {
+			return m, ErrTeamNotFound
+		}
+		if err != nil {
+			return m, err
+		}
+
+		cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, bson.M{"_id": bson.M{"$in": team.Squad}})
+		if err != nil {
+			return m, err
+		}
+		var squad []models.Player
+		if err := cursor.All(ctx, &squad); err != nil {
+			return m, err
+		}
+
+		side := Side{TeamID: team.ID, Name: team.TeamName, Players: make([]Player, 0, len(squad))}
+		for _, p := range squad {
+			side.Players = append(side.Players, Player{ID: p.Id, Name: p.PlayerName})
+		}
+		m.Sides[i] = side
+		if id == lm.BattingFirst {
+			m.BattingFirst = i
+		}
+	}
+	return m, nil
+}
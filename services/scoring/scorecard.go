@@ -0,0 +1,52 @@
+package scoring
+
+import (
+	"cric-auction-monolith/services/manual"
+)
+
+// Scorecard writes the cards out as a manual scorecard, which builds into
+// the scorecard the fantasy calculator reads. Batters still in are not out.
+func (s *State) Scorecard() manual.Scorecard {
+	sc := manual.Scorecard{
+		Status:  s.Result,
+		Innings: make([]manual.Innings, 0, len(s.Innings)),
+	}
+	for _, inn := range s.Innings {
+		if len(inn.Batting) == 0 && len(inn.Bowling) == 0 {
+			continue // the chase never started
+		}
+		mi := manual.Innings{
+			Team:    inn.Team,
+			Extras:  inn.Extras,
+			Batting: make([]manual.Batting, 0, len(inn.Batting)),
+			Bowling: make([]manual.Bowling, 0, len(inn.Bowling)),
+		}
+		for _, b := range inn.Batting {
+			dismissal := b.Dismissal
+			if dismissal == "" {
+				dismissal = "not out"
+			}
+			mi.Batting = append(mi.Batting, manual.Batting{
+				PlayerID:  b.PlayerID,
+				Name:      b.Name,
+				Runs:      b.Runs,
+				Balls:     b.Balls,
+				Fours:     b.Fours,
+				Sixes:     b.Sixes,
+				Dismissal: dismissal,
+			})
+		}
+		for _, b := range inn.Bowling {
+			mi.Bowling = append(mi.Bowling, manual.Bowling{
+				PlayerID: b.PlayerID,
+				Name:     b.Name,
+				Overs:    b.Overs,
+				Maidens:  b.Maidens,
+				Runs:     b.Runs,
+				Dots:     b.Dots,
+			})
+		}
+		sc.Innings = append(sc.Innings, mi)
+	}
+	return sc
+}
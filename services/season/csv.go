@@ -0,0 +1,105 @@
+package season
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"cric-auction-monolith/pkg/models"
+)
+
+// CSV columns. date, team1 and team2 are required.
+const (
+	colDate            = "date"
+	colVenue           = "venue"
+	colTeam1           = "team1"
+	colTeam2           = "team2"
+	colProviderMatchID = "provider_match_id"
+	colMatchDesc       = "match_desc"
+)
+
+// dateLayouts are the accepted date formats, tried in order. Dates without
+// a zone are UTC.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04 -0700",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseCSV reads fixtures from a CSV with a header row naming its columns:
+// date, venue, team1, team2, provider_match_id and optionally match_desc.
+// Errors name the line they were found on.
+func ParseCSV(r io.Reader, providerName string) ([]models.SeasonFixture, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{colDate, colTeam1, colTeam2} {
+		if _, ok := cols[name]; !ok {
+			return nil, fmt.Errorf("csv is missing the %s column", name)
+		}
+	}
+
+	fixtures := []models.SeasonFixture{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		f := models.SeasonFixture{
+			MatchDesc: field(colMatchDesc),
+			Venue:     field(colVenue),
+			Team1:     field(colTeam1),
+			Team2:     field(colTeam2),
+		}
+		if f.Team1 == "" || f.Team2 == "" {
+			return nil, fmt.Errorf("line %d: team1 and team2 are required", line)
+		}
+		if f.StartTime, err = parseDate(field(colDate)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if id := field(colProviderMatchID); id != "" {
+			if f.ProviderMatchID, err = strconv.Atoi(id); err != nil || f.ProviderMatchID <= 0 {
+				return nil, fmt.Errorf("line %d: invalid provider_match_id %q", line, id)
+			}
+			f.Provider = providerName
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,166 @@
+package season
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/cricbuzz"
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotFound is returned when an auction has no season or a fixture does
+// not exist.
+var ErrNotFound = errors.New("season not found")
+
+// FromProvider turns the schedule of a series into fixtures. Matches
+// without both teams, such as playoffs still to be decided, are skipped.
+func FromProvider(providerName string, resp *cricbuzz.SeriesMatchesResponse) []models.SeasonFixture {
+	fixtures := []models.SeasonFixture{}
+	for _, day := range resp.MatchDetails {
+		if day.MatchDetailsMap == nil {
+			continue
+		}
+		for _, m := range day.MatchDetailsMap.Match {
+			info := m.MatchInfo
+			if info.Team1.TeamSName == "" || info.Team2.TeamSName == "" {
+				continue
+			}
+			f := models.SeasonFixture{
+				MatchDesc:       info.MatchDesc,
+				Team1:           info.Team1.TeamSName,
+				Team2:           info.Team2.TeamSName,
+				Provider:        providerName,
+				ProviderMatchID: info.MatchID,
+			}
+			if ms, err := strconv.ParseInt(info.StartDate, 10, 64); err == nil {
+				f.StartTime = time.UnixMilli(ms).UTC()
+			}
+			if info.VenueInfo != nil {
+				f.Venue = info.VenueInfo.Ground
+				if info.VenueInfo.City != "" {
+					f.Venue += ", " + info.VenueInfo.City
+				}
+			}
+			fixtures = append(fixtures, f)
+		}
+	}
+	return fixtures
+}
+
+// Import stores the season of an auction and replaces its fixtures. Team
+// names are stored as the franchise short names so they compare with the
+// players' IPL teams, and fixtures are ordered by start time.
+func Import(ctx context.Context, db *mongo.Database, s models.Season, fixtures []models.SeasonFixture, teams fantasy.TeamAliases) (*models.Season, []models.SeasonFixture, error) {
+	now := time.Now()
+	s.ImportedAt = now
+	s.UpdatedAt = now
+
+	var existing models.Season
+	err := db.Collection(constants.SeasonCollection).FindOne(ctx, bson.M{"auction_id": s.AuctionID}).Decode(&existing)
+	switch {
+	case err == mongo.ErrNoDocuments:
+		s.ID = primitive.NewObjectID()
+		s.CreatedAt = now
+	case err != nil:
+		return nil, nil, err
+	default:
+		s.ID = existing.ID
+		s.CreatedAt = existing.CreatedAt
+	}
+
+	_, err = db.Collection(constants.SeasonCollection).ReplaceOne(ctx,
+		bson.M{"_id": s.ID}, s, options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		return fixtures[i].StartTime.Before(fixtures[j].StartTime)
+	})
+	docs := make([]any, 0, len(fixtures))
+	for i := range fixtures {
+		f := &fixtures[i]
+		f.ID = primitive.NewObjectID()
+		f.SeasonID = s.ID
+		f.AuctionID = s.AuctionID
+		f.Team1 = teams.Short(f.Team1)
+		f.Team2 = teams.Short(f.Team2)
+		docs = append(docs, f)
+	}
+
+	coll := db.Collection(constants.SeasonFixtureCollection)
+	if _, err := coll.DeleteMany(ctx, bson.M{"season_id": s.ID}); err != nil {
+		return nil, nil, err
+	}
+	if len(docs) > 0 {
+		if _, err := coll.InsertMany(ctx, docs); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &s, fixtures, nil
+}
+
+// Load returns the season of an auction with its fixtures in start order.
+func Load(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (*models.Season, []models.SeasonFixture, error) {
+	var s models.Season
+	err := db.Collection(constants.SeasonCollection).FindOne(ctx, bson.M{"auction_id": auctionID}).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cursor, err := db.Collection(constants.SeasonFixtureCollection).Find(ctx,
+		bson.M{"season_id": s.ID},
+		options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	fixtures := []models.SeasonFixture{}
+	if err := cursor.All(ctx, &fixtures); err != nil {
+		return nil, nil, err
+	}
+	return &s, fixtures, nil
+}
+
+// Fixtures returns the fixtures of an auction with the given IDs. It fails
+// with ErrNotFound when any of them is not a fixture of the auction.
+func Fixtures(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, ids []primitive.ObjectID) ([]models.SeasonFixture, error) {
+	cursor, err := db.Collection(constants.SeasonFixtureCollection).Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	fixtures := []models.SeasonFixture{}
+	if err := cursor.All(ctx, &fixtures); err != nil {
+		return nil, err
+	}
+	if len(fixtures) != len(ids) {
+		return nil, ErrNotFound
+	}
+	return fixtures, nil
+}
+
+// Fixture returns one fixture of an auction.
+func Fixture(ctx context.Context, db *mongo.Database, auctionID, id primitive.ObjectID) (*models.SeasonFixture, error) {
+	fixtures, err := Fixtures(ctx, db, auctionID, []primitive.ObjectID{id})
+	if err != nil {
+		return nil, err
+	}
+	return &fixtures[0], nil
+}
@@ -0,0 +1,200 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Season awards.
+const (
+	AwardOrangeCap  = "orange_cap"  // most runs
+	AwardPurpleCap  = "purple_cap"  // most wickets
+	AwardMVP        = "mvp"         // most fantasy points
+	AwardStrikeRate = "strike_rate" // best strike rate over the minimum balls
+	AwardCatches    = "catches"     // most catches
+	AwardBestBuy    = "best_buy"    // most fantasy points per crore spent
+)
+
+// Options tune the awards. MinBalls is the balls a batter must face to
+// qualify for the strike-rate award; Limit is the leaders listed per award.
+type Options struct {
+	MinBalls int `json:"min_balls"`
+	Limit    int `json:"limit"`
+}
+
+// DefaultOptions list the top five and need 60 balls faced for the
+// strike-rate award.
+func DefaultOptions() Options {
+	return Options{MinBalls: 60, Limit: 5}
+}
+
+// Award is one season award with its leaders, best first.
+type Award struct {
+	Key     string   `json:"key"`
+	Title   string   `json:"title"`
+	Leaders []Leader `json:"leaders"`
+}
+
+// Leader is a player's standing for an award.
+type Leader struct {
+	Rank       int                `json:"rank"`
+	PlayerID   primitive.ObjectID `json:"player_id"`
+	PlayerName string             `json:"player_name"`
+	TeamName   string             `json:"team_name,omitempty"`
+	IPLTeam    string             `json:"ipl_team,omitempty"`
+	Value      float64            `json:"value"`
+	Detail     string             `json:"detail"`
+}
+
+// award ranks the players value qualifies by their value, higher first, then
+// by tie. Players level on both share a rank.
+type award struct {
+	key, title string
+	value      func(p Player) (float64, bool)
+	tie        func(a, b Player) int // > 0 when a is ahead
+	detail     func(p Player) string
+}
+
+var awards = []award{
+	{
+		key: AwardOrangeCap, title: "Orange Cap",
+		value: func(p Player) (float64, bool) { return float64(p.Runs), p.Runs > 0 },
+		tie:   func(a, b Player) int { return b.Balls - a.Balls },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d runs off %d balls in %d %s", p.Runs, p.Balls, p.Innings, plural("innings", p.Innings))
+		},
+	},
+	{
+		key: AwardPurpleCap, title: "Purple Cap",
+		value: func(p Player) (float64, bool) { return float64(p.Wickets), p.Wickets > 0 },
+		tie:   func(a, b Player) int { return b.RunsConceded - a.RunsConceded },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d %s for %d runs in %s overs", p.Wickets, plural("wicket", p.Wickets), p.RunsConceded, overs(p.BallsBowled))
+		},
+	},
+	{
+		key: AwardMVP, title: "Most Valuable Player",
+		value: func(p Player) (float64, bool) { return float64(p.Points), p.Matches > 0 },
+		tie:   func(a, b Player) int { return b.Matches - a.Matches },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d pts in %d %s", p.Points, p.Matches, plural("match", p.Matches))
+		},
+	},
+	{
+		key: AwardStrikeRate, title: "Best Strike Rate",
+		tie: func(a, b Player) int { return a.Runs - b.Runs },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d runs off %d balls", p.Runs, p.Balls)
+		},
+	},
+	{
+		key: AwardCatches, title: "Most Catches",
+		value: func(p Player) (float64, bool) { return float64(p.Catches), p.Catches > 0 },
+		tie:   func(a, b Player) int { return b.Matches - a.Matches },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d %s in %d %s", p.Catches, plural("catch", p.Catches), p.Matches, plural("match", p.Matches))
+		},
+	},
+	{
+		key: AwardBestBuy, title: "Best Buy",
+		value: func(p Player) (float64, bool) {
+			if p.SellingPrice <= 0 || p.Points <= 0 {
+				return 0, false
+			}
+			return round2(float64(p.Points) / p.SellingPrice), true
+		},
+		tie: func(a, b Player) int { return a.Points - b.Points },
+		detail: func(p Player) string {
+			return fmt.Sprintf("%d pts for %.2f cr", p.Points, p.SellingPrice)
+		},
+	},
+}
+
+// Awards ranks the players for every season award.
+func Awards(players []Player, opts Options) []Award {
+	out := make([]Award, 0, len(awards))
+	for _, a := range awards {
+		if a.key == AwardStrikeRate {
+			minBalls := opts.MinBalls
+			a.value = func(p Player) (float64, bool) {
+				if p.Balls == 0 || p.Balls < minBalls {
+					return 0, false
+				}
+				return p.StrikeRate, true
+			}
+		}
+		out = append(out, Award{Key: a.key, Title: a.title, Leaders: a.rank(players, opts.Limit)})
+	}
+	return out
+}
+
+func (a award) rank(players []Player, limit int) []Leader {
+	type entry struct {
+		p     Player
+		value float64
+	}
+	var entries []entry
+	for _, p := range players {
+		if v, ok := a.value(p); ok {
+			entries = append(entries, entry{p, v})
+		}
+	}
+	compare := func(x, y entry) int {
+		if x.value != y.value {
+			if x.value > y.value {
+				return 1
+			}
+			return -1
+		}
+		return a.tie(x.p, y.p)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if c := compare(entries[i], entries[j]); c != 0 {
+			return c > 0
+		}
+		return entries[i].p.PlayerName < entries[j].p.PlayerName
+	})
+
+	leaders := []Leader{}
+	for i, e := range entries {
+		rank := i + 1
+		if i > 0 && compare(e, entries[i-1]) == 0 {
+			rank = leaders[i-1].Rank
+		}
+		// Past the limit, only players sharing the last listed rank stay.
+		if limit > 0 && i >= limit && rank != leaders[i-1].Rank {
+			break
+		}
+		leaders = append(leaders, Leader{
+			Rank:       rank,
+			PlayerID:   e.p.PlayerID,
+			PlayerName: e.p.PlayerName,
+			TeamName:   e.p.TeamName,
+			IPLTeam:    e.p.IPLTeam,
+			Value:      e.value,
+			Detail:     a.detail(e.p),
+		})
+	}
+	return leaders
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func overs(balls int) string {
+	return fmt.Sprintf("%d.%d", balls/6, balls%6)
+}
+
+func plural(word string, n int) string {
+	if n == 1 || word == "innings" {
+		return word
+	}
+	if word == "match" || word == "catch" {
+		return word + "es"
+	}
+	return word + "s"
+}
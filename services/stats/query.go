@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sort keys for Sort.
+const (
+	SortPoints     = "points"
+	SortMatches    = "matches"
+	SortRuns       = "runs"
+	SortAverage    = "average"
+	SortStrikeRate = "strike_rate"
+	SortSixes      = "sixes"
+	SortWickets    = "wickets"
+	SortEconomy    = "economy"
+	SortCatches    = "catches"
+	SortName       = "name"
+)
+
+// Sort orders.
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type sortKey struct {
+	value func(p Player) float64
+	// qualifies is false for players the key says nothing about, e.g. the
+	// economy of a player who never bowled; they sort last either way.
+	qualifies func(p Player) bool
+	// asc is the key's natural order.
+	asc bool
+}
+
+func always(Player) bool { return true }
+
+var sortKeys = map[string]sortKey{
+	SortPoints:     {value: func(p Player) float64 { return float64(p.Points) }, qualifies: always},
+	SortMatches:    {value: func(p Player) float64 { return float64(p.Matches) }, qualifies: always},
+	SortRuns:       {value: func(p Player) float64 { return float64(p.Runs) }, qualifies: always},
+	SortAverage:    {value: func(p Player) float64 { return p.Average }, qualifies: func(p Player) bool { return p.Innings > p.NotOuts }},
+	SortStrikeRate: {value: func(p Player) float64 { return p.StrikeRate }, qualifies: func(p Player) bool { return p.Balls > 0 }},
+	SortSixes:      {value: func(p Player) float64 { return float64(p.Sixes) }, qualifies: always},
+	SortWickets:    {value: func(p Player) float64 { return float64(p.Wickets) }, qualifies: always},
+	SortEconomy:    {value: func(p Player) float64 { return p.Economy }, qualifies: func(p Player) bool { return p.BallsBowled > 0 }, asc: true},
+	SortCatches:    {value: func(p Player) float64 { return float64(p.Catches) }, qualifies: always},
+}
+
+// Filter selects players by role, IPL team or owning team. Empty fields
+// match every player; roles and IPL teams compare without regard to case.
+type Filter struct {
+	Role    string             `json:"role"`
+	IPLTeam string             `json:"ipl_team"`
+	TeamID  primitive.ObjectID `json:"team_id"`
+}
+
+// Apply returns the players matching the filter.
+func (f Filter) Apply(players []Player) []Player {
+	out := make([]Player, 0, len(players))
+	for _, p := range players {
+		if f.Role != "" && !strings.EqualFold(p.Role, f.Role) {
+			continue
+		}
+		if f.IPLTeam != "" && !strings.EqualFold(p.IPLTeam, f.IPLTeam) {
+			continue
+		}
+		if !f.TeamID.IsZero() && p.TeamID != f.TeamID {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Sort orders the players by key, in order or the key's natural order when
+// order is empty: highest first for everything but economy and name. Ties
+// go by name.
+func Sort(players []Player, key, order string) error {
+	if key == "" {
+		key = SortPoints
+	}
+	if order != "" && order != OrderAsc && order != OrderDesc {
+		return fmt.Errorf("unknown order %q", order)
+	}
+	if key == SortName {
+		desc := order == OrderDesc
+		sort.SliceStable(players, func(i, j int) bool {
+			if desc {
+				return players[i].PlayerName > players[j].PlayerName
+			}
+			return players[i].PlayerName < players[j].PlayerName
+		})
+		return nil
+	}
+
+	k, ok := sortKeys[key]
+	if !ok {
+		return fmt.Errorf("unknown sort key %q", key)
+	}
+	asc := k.asc
+	if order != "" {
+		asc = order == OrderAsc
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		qa, qb := k.qualifies(a), k.qualifies(b)
+		if qa != qb {
+			return qa
+		}
+		if va, vb := k.value(a), k.value(b); qa && va != vb {
+			if asc {
+				return va < vb
+			}
+			return va > vb
+		}
+		return a.PlayerName < b.PlayerName
+	})
+	return nil
+}
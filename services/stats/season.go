@@ -0,0 +1,229 @@
+// Package stats aggregates the season figures of an auction's players from
+// the scorecards their points were calculated from, and ranks them for the
+// season awards.
+package stats
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/fantasy"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Player is an auction player's season: the matches their points were
+// calculated for, the fantasy points from them and the figures off the
+// scorecards.
+type Player struct {
+	PlayerID     primitive.ObjectID `json:"player_id"`
+	PlayerName   string             `json:"player_name"`
+	Role         string             `json:"role"`
+	IPLTeam      string             `json:"ipl_team,omitempty"`
+	TeamID       primitive.ObjectID `json:"team_id,omitempty"`
+	TeamName     string             `json:"team_name,omitempty"`
+	SellingPrice float64            `json:"selling_price"`
+	Matches      int                `json:"matches"`
+	Points       int                `json:"points"`
+	fantasy.PlayerStats
+	// Average is runs per dismissal, StrikeRate runs per 100 balls and
+	// Economy runs conceded per over; 0 when there is nothing to divide by.
+	Average    float64 `json:"average"`
+	StrikeRate float64 `json:"strike_rate"`
+	Economy    float64 `json:"economy"`
+}
+
+// MatchLine is a player's figures and points in one match.
+type MatchLine struct {
+	Provider        string    `json:"provider"`
+	ProviderMatchID int       `json:"provider_match_id"`
+	Gameweek        int       `json:"gameweek"`
+	Points          int       `json:"points"`
+	CountedAs       string    `json:"counted_as,omitempty"`
+	CalculatedAt    time.Time `json:"calculated_at"`
+	// Scorecard is false when the match's scorecard is no longer stored and
+	// only the points are known.
+	Scorecard bool `json:"scorecard"`
+	fantasy.PlayerStats
+}
+
+// ErrNotFound is returned when the player is not one of the auction's.
+var ErrNotFound = errors.New("player not found")
+
+type matchKey struct {
+	provider string
+	id       int
+}
+
+// Season returns the season of every player of the auction, in no
+// particular order. Players without a calculated match have zero figures.
+// A scorecard that is no longer stored still counts for matches and points.
+func Season(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, rules fantasy.Rules) ([]Player, error) {
+	season, _, err := load(ctx, db, bson.M{"auction_id": auctionID}, rules)
+	return season, err
+}
+
+// PlayerSeason returns one player's season and their line for every match,
+// oldest first.
+func PlayerSeason(ctx context.Context, db *mongo.Database, auctionID, playerID primitive.ObjectID, rules fantasy.Rules) (*Player, []MatchLine, error) {
+	season, lines, err := load(ctx, db, bson.M{"_id": playerID, "auction_id": auctionID}, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(season) == 0 {
+		return nil, nil, ErrNotFound
+	}
+	out := lines[playerID]
+	if out == nil {
+		out = []MatchLine{}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CalculatedAt.Before(out[j].CalculatedAt) })
+	return &season[0], out, nil
+}
+
+// load aggregates the season of the players the filter selects, with each
+// player's match lines.
+func load(ctx context.Context, db *mongo.Database, filter bson.M, rules fantasy.Rules) ([]Player, map[primitive.ObjectID][]MatchLine, error) {
+	cursor, err := db.Collection(constants.PlayerCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, nil, err
+	}
+	if len(players) == 0 {
+		return []Player{}, nil, nil
+	}
+	auctionID := players[0].AuctionId
+
+	cursor, err = db.Collection(constants.TeamCollection).Find(ctx, bson.M{"auction_id": auctionID})
+	if err != nil {
+		return nil, nil, err
+	}
+	var teams []models.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, nil, err
+	}
+	owner := make(map[primitive.ObjectID]models.Team)
+	for _, t := range teams {
+		for _, id := range t.Squad {
+			owner[id] = t
+		}
+	}
+
+	season := make([]Player, 0, len(players))
+	index := make(map[primitive.ObjectID]int, len(players))
+	ids := make([]primitive.ObjectID, 0, len(players))
+	for _, p := range players {
+		ids = append(ids, p.Id)
+		index[p.Id] = len(season)
+		t := owner[p.Id]
+		season = append(season, Player{
+			PlayerID:     p.Id,
+			PlayerName:   p.PlayerName,
+			Role:         p.Role,
+			IPLTeam:      p.IPLTeam,
+			TeamID:       t.ID,
+			TeamName:     t.TeamName,
+			SellingPrice: p.SellingPrice,
+		})
+	}
+
+	cursor, err = db.Collection(constants.LedgerCollection).Find(ctx,
+		bson.M{"auction_id": auctionID, "player_id": bson.M{"$in": ids}},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entries []models.PointsEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, nil, err
+	}
+
+	cards, err := matchStats(ctx, db, entries, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := make(map[primitive.ObjectID][]MatchLine)
+	for _, e := range entries {
+		i, ok := index[e.PlayerID]
+		if !ok {
+			continue
+		}
+		card := cards[matchKey{e.Provider, e.ProviderMatchID}]
+		line := MatchLine{
+			Provider:        e.Provider,
+			ProviderMatchID: e.ProviderMatchID,
+			Gameweek:        e.Gameweek,
+			Points:          e.CalculatedPoints,
+			CountedAs:       e.CountedAs,
+			CalculatedAt:    e.CalculatedAt,
+			Scorecard:       card != nil,
+		}
+		line.PlayerStats, _ = fantasy.Lookup(card, e.CricbuzzName)
+		lines[e.PlayerID] = append(lines[e.PlayerID], line)
+
+		p := &season[i]
+		p.Matches++
+		p.Points += e.CalculatedPoints
+		p.PlayerStats.Add(line.PlayerStats)
+	}
+	for i := range season {
+		season[i].derive()
+	}
+	return season, lines, nil
+}
+
+// derive works out the rates from the totals.
+func (p *Player) derive() {
+	p.Average, p.StrikeRate, p.Economy = 0, 0, 0
+	if outs := p.Innings - p.NotOuts; outs > 0 {
+		p.Average = round2(float64(p.Runs) / float64(outs))
+	}
+	if p.Balls > 0 {
+		p.StrikeRate = round2(float64(p.Runs) * 100 / float64(p.Balls))
+	}
+	if p.BallsBowled > 0 {
+		p.Economy = round2(float64(p.RunsConceded) * 6 / float64(p.BallsBowled))
+	}
+}
+
+// matchStats reads the figures off the stored scorecard of every match in
+// the entries.
+func matchStats(ctx context.Context, db *mongo.Database, entries []models.PointsEntry, rules fantasy.Rules) (map[matchKey]map[string]*fantasy.PlayerStats, error) {
+	cards := make(map[matchKey]map[string]*fantasy.PlayerStats)
+	var or []bson.M
+	for _, e := range entries {
+		key := matchKey{e.Provider, e.ProviderMatchID}
+		if _, ok := cards[key]; ok {
+			continue
+		}
+		cards[key] = nil
+		or = append(or, bson.M{"provider": e.Provider, "provider_match_id": e.ProviderMatchID})
+	}
+	if len(or) == 0 {
+		return cards, nil
+	}
+
+	cursor, err := db.Collection(constants.ScorecardCollection).Find(ctx, bson.M{"$or": or})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var stored models.Scorecard
+		if err := cursor.Decode(&stored); err != nil {
+			return nil, err
+		}
+		cards[matchKey{stored.Provider, stored.ProviderMatchID}] = fantasy.MatchStats(&stored.Scorecard, rules)
+	}
+	return cards, cursor.Err()
+}
@@ -0,0 +1,192 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cric-auction-monolith/core/constants"
+	"cric-auction-monolith/pkg/models"
+	"cric-auction-monolith/services/h2h"
+	"cric-auction-monolith/services/scoring"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotFound is returned when a fixture is not one of the auction's.
+var ErrNotFound = errors.New("fixture not found")
+
+// Fixture is a club fixture with its team names.
+type Fixture struct {
+	models.ClubFixture
+	Team1Name string `json:"team1_name"`
+	Team2Name string `json:"team2_name"`
+}
+
+// teams returns the auction's teams in the order they were created.
+func teams(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]models.Team, error) {
+	cursor, err := db.Collection(constants.TeamCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var out []models.Team
+	err = cursor.All(ctx, &out)
+	return out, err
+}
+
+// Generate replaces the auction's unplayed fixtures with a round-robin
+// schedule of the given number of cycles, numbered on from the last round
+// with a result. Played fixtures are kept so the table keeps their results.
+func Generate(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, cycles, overs int) ([]models.ClubFixture, error) {
+	all, err := teams(ctx, db, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(all))
+	for _, t := range all {
+		ids = append(ids, t.ID)
+	}
+
+	coll := db.Collection(constants.ClubFixtureCollection)
+	if _, err := coll.DeleteMany(ctx, bson.M{"auction_id": auctionID, "result": nil}); err != nil {
+		return nil, err
+	}
+
+	start := 1
+	var last models.ClubFixture
+	err = coll.FindOne(ctx,
+		bson.M{"auction_id": auctionID},
+		options.FindOne().SetSort(bson.M{"round": -1}),
+	).Decode(&last)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	if err == nil {
+		start = last.Round + 1
+	}
+
+	fixtures := []models.ClubFixture{}
+	now := time.Now()
+	round := start
+	for _, pairs := range h2h.RoundRobin(ids, cycles) {
+		played := false
+		for _, p := range pairs {
+			if p.Away.IsZero() {
+				continue
+			}
+			fixtures = append(fixtures, models.ClubFixture{
+				AuctionID: auctionID,
+				Round:     round,
+				Team1ID:   p.Home,
+				Team2ID:   p.Away,
+				Overs:     overs,
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+			played = true
+		}
+		if played {
+			round++
+		}
+	}
+	if len(fixtures) == 0 {
+		return fixtures, nil
+	}
+
+	docs := make([]any, 0, len(fixtures))
+	for _, f := range fixtures {
+		docs = append(docs, f)
+	}
+	res, err := coll.InsertMany(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range res.InsertedIDs {
+		fixtures[i].ID = id.(primitive.ObjectID)
+	}
+	return fixtures, nil
+}
+
+// Get fetches one fixture of an auction.
+func Get(ctx context.Context, db *mongo.Database, auctionID, fixtureID primitive.ObjectID) (*models.ClubFixture, error) {
+	var f models.ClubFixture
+	err := db.Collection(constants.ClubFixtureCollection).FindOne(ctx,
+		bson.M{"_id": fixtureID, "auction_id": auctionID},
+	).Decode(&f)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Load returns every fixture of the auction, by round, and the standings
+// from the played ones.
+func Load(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) ([]Fixture, []Row, Rules, error) {
+	rules, err := LoadRules(ctx, db, auctionID)
+	if err != nil {
+		return nil, nil, rules, err
+	}
+
+	all, err := teams(ctx, db, auctionID)
+	if err != nil {
+		return nil, nil, rules, err
+	}
+	names := make(map[primitive.ObjectID]string, len(all))
+	rows := make([]Row, 0, len(all))
+	for _, t := range all {
+		names[t.ID] = t.TeamName
+		rows = append(rows, Row{TeamID: t.ID, TeamName: t.TeamName})
+	}
+
+	cursor, err := db.Collection(constants.ClubFixtureCollection).Find(ctx,
+		bson.M{"auction_id": auctionID},
+		options.Find().SetSort(bson.D{{Key: "round", Value: 1}, {Key: "start_time", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, nil, rules, err
+	}
+	var played []models.ClubFixture
+	if err := cursor.All(ctx, &played); err != nil {
+		return nil, nil, rules, err
+	}
+
+	fixtures := make([]Fixture, 0, len(played))
+	for _, f := range played {
+		fixtures = append(fixtures, Fixture{ClubFixture: f, Team1Name: names[f.Team1ID], Team2Name: names[f.Team2ID]})
+	}
+	return fixtures, Table(rows, played, rules), rules, nil
+}
+
+// FromLive turns a completed live match between the fixture's teams into
+// its result.
+func FromLive(f *models.ClubFixture, lm *models.LiveMatch, state *scoring.State) (models.ClubResult, error) {
+	if lm.Status != models.LiveMatchComplete || !state.Complete {
+		return models.ClubResult{}, errors.New("the live match is not complete")
+	}
+	same := lm.Team1ID == f.Team1ID && lm.Team2ID == f.Team2ID ||
+		lm.Team1ID == f.Team2ID && lm.Team2ID == f.Team1ID
+	if !same {
+		return models.ClubResult{}, errors.New("the live match was not played between the fixture's teams")
+	}
+
+	res := models.ClubResult{LiveMatchID: lm.ID}
+	for _, inn := range state.Innings {
+		ci := models.ClubInnings{Runs: inn.Runs, Wickets: inn.Wickets, Overs: inn.Overs, AllOut: inn.AllOut}
+		if inn.TeamID == f.Team1ID {
+			res.Team1 = ci
+		} else {
+			res.Team2 = ci
+		}
+	}
+	res.Outcome = Decide(res.Team1, res.Team2)
+	return res, nil
+}
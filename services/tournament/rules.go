@@ -0,0 +1,83 @@
+// Package tournament runs the club tournament of an auction: fixtures and
+// results between its teams and a standings table with net run rate.
+package tournament
+
+import (
+	"context"
+	"fmt"
+
+	"cric-auction-monolith/core/constants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Tie-breakers, applied in the configured order to teams level on points.
+const (
+	TieBreakWins        = "wins"
+	TieBreakNRR         = "nrr"
+	TieBreakHeadToHead  = "head_to_head" // points taken in the games between the tied teams
+	TieBreakRunsFor     = "runs_for"
+	TieBreakWicketsLost = "wickets_lost" // fewer is better
+)
+
+var tieBreakers = map[string]bool{
+	TieBreakWins:        true,
+	TieBreakNRR:         true,
+	TieBreakHeadToHead:  true,
+	TieBreakRunsFor:     true,
+	TieBreakWicketsLost: true,
+}
+
+// Rules are the tournament points for each result and the order of the
+// tie-breakers.
+type Rules struct {
+	Win         int      `bson:"win" json:"win"`
+	Tie         int      `bson:"tie" json:"tie"`
+	NoResult    int      `bson:"no_result" json:"no_result"`
+	Loss        int      `bson:"loss" json:"loss"`
+	TieBreakers []string `bson:"tie_breakers" json:"tie_breakers"`
+}
+
+// DefaultRules award 2 for a win, 1 for a tie or no result and break ties
+// on wins, then net run rate, then head-to-head.
+func DefaultRules() Rules {
+	return Rules{
+		Win:         2,
+		Tie:         1,
+		NoResult:    1,
+		Loss:        0,
+		TieBreakers: []string{TieBreakWins, TieBreakNRR, TieBreakHeadToHead},
+	}
+}
+
+// Validate checks that the tie-breakers are known and not repeated.
+func (r Rules) Validate() error {
+	seen := make(map[string]bool, len(r.TieBreakers))
+	for _, tb := range r.TieBreakers {
+		if !tieBreakers[tb] {
+			return fmt.Errorf("unknown tie-breaker %q", tb)
+		}
+		if seen[tb] {
+			return fmt.Errorf("tie-breaker %q is listed twice", tb)
+		}
+		seen[tb] = true
+	}
+	return nil
+}
+
+// LoadRules reads the tournament_rules of an auction on top of the default
+// rules.
+func LoadRules(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID) (Rules, error) {
+	doc := struct {
+		TournamentRules Rules `bson:"tournament_rules"`
+	}{TournamentRules: DefaultRules()}
+
+	err := db.Collection(constants.AuctionCollection).FindOne(ctx,
+		bson.M{"_id": auctionID},
+		options.FindOne().SetProjection(bson.M{"tournament_rules": 1}),
+	).Decode(&doc)
+	return doc.TournamentRules, err
+}
@@ -0,0 +1,248 @@
+package tournament
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Row is a team's line in the standings.
+type Row struct {
+	Rank         int                `json:"rank"`
+	TeamID       primitive.ObjectID `json:"team_id"`
+	TeamName     string             `json:"team_name"`
+	Played       int                `json:"played"`
+	Won          int                `json:"won"`
+	Lost         int                `json:"lost"`
+	Tied         int                `json:"tied"`
+	NoResult     int                `json:"no_result"`
+	Points       int                `json:"points"`
+	RunsFor      int                `json:"runs_for"`
+	OversFor     string             `json:"overs_for"`
+	RunsAgainst  int                `json:"runs_against"`
+	OversAgainst string             `json:"overs_against"`
+	WicketsLost  int                `json:"wickets_lost"`
+	NRR          float64            `json:"nrr"`
+
+	ballsFor     int
+	ballsAgainst int
+}
+
+// Decide returns the outcome of a completed match from the two innings.
+func Decide(team1, team2 models.ClubInnings) string {
+	switch {
+	case team1.Runs > team2.Runs:
+		return models.OutcomeTeam1
+	case team2.Runs > team1.Runs:
+		return models.OutcomeTeam2
+	}
+	return models.OutcomeTie
+}
+
+// ParseOvers converts overs in the 12.4 notation to balls.
+func ParseOvers(s string) (int, error) {
+	whole, part, found := strings.Cut(strings.TrimSpace(s), ".")
+	overs, err := strconv.Atoi(whole)
+	if err != nil || overs < 0 {
+		return 0, errors.New("overs must look like 12 or 12.4")
+	}
+	balls := 0
+	if found {
+		balls, err = strconv.Atoi(part)
+		if err != nil || len(part) != 1 || balls > 5 {
+			return 0, errors.New("overs must look like 12 or 12.4")
+		}
+	}
+	return overs*6 + balls, nil
+}
+
+// Table builds the standings from the played fixtures. Teams are ordered by
+// points, then by the rules' tie-breakers in order, then by name; teams
+// level on all of them share a rank.
+//
+// Net run rate is runs scored per over faced minus runs conceded per over
+// bowled. A side bowled out counts as having faced its full overs, and
+// no-result matches do not count towards it.
+func Table(teams []Row, fixtures []models.ClubFixture, rules Rules) []Row {
+	rows := make(map[primitive.ObjectID]*Row, len(teams))
+	order := make([]primitive.ObjectID, 0, len(teams))
+	for _, t := range teams {
+		t := t
+		rows[t.TeamID] = &t
+		order = append(order, t.TeamID)
+	}
+	row := func(id primitive.ObjectID) *Row {
+		r := rows[id]
+		if r == nil {
+			r = &Row{TeamID: id}
+			rows[id] = r
+			order = append(order, id)
+		}
+		return r
+	}
+
+	// meetings[a][b] are the points a took from games against b.
+	meetings := make(map[primitive.ObjectID]map[primitive.ObjectID]int)
+	award := func(a, b *Row, pts int) {
+		a.Points += pts
+		if meetings[a.TeamID] == nil {
+			meetings[a.TeamID] = make(map[primitive.ObjectID]int)
+		}
+		meetings[a.TeamID][b.TeamID] += pts
+	}
+
+	for _, f := range fixtures {
+		if f.Result == nil {
+			continue
+		}
+		res := f.Result
+		t1, t2 := row(f.Team1ID), row(f.Team2ID)
+		t1.Played++
+		t2.Played++
+
+		switch res.Outcome {
+		case models.OutcomeNoResult:
+			t1.NoResult++
+			t2.NoResult++
+			award(t1, t2, rules.NoResult)
+			award(t2, t1, rules.NoResult)
+			continue
+		case models.OutcomeTeam1:
+			t1.Won++
+			t2.Lost++
+			award(t1, t2, rules.Win)
+			award(t2, t1, rules.Loss)
+		case models.OutcomeTeam2:
+			t2.Won++
+			t1.Lost++
+			award(t2, t1, rules.Win)
+			award(t1, t2, rules.Loss)
+		default:
+			t1.Tied++
+			t2.Tied++
+			award(t1, t2, rules.Tie)
+			award(t2, t1, rules.Tie)
+		}
+
+		b1, b2 := facedBalls(res.Team1, f.Overs), facedBalls(res.Team2, f.Overs)
+		t1.RunsFor += res.Team1.Runs
+		t1.ballsFor += b1
+		t1.RunsAgainst += res.Team2.Runs
+		t1.ballsAgainst += b2
+		t1.WicketsLost += res.Team1.Wickets
+		t2.RunsFor += res.Team2.Runs
+		t2.ballsFor += b2
+		t2.RunsAgainst += res.Team1.Runs
+		t2.ballsAgainst += b1
+		t2.WicketsLost += res.Team2.Wickets
+	}
+
+	table := make([]Row, 0, len(order))
+	for _, id := range order {
+		r := rows[id]
+		r.OversFor = overs(r.ballsFor)
+		r.OversAgainst = overs(r.ballsAgainst)
+		r.NRR = math.Round((perOver(r.RunsFor, r.ballsFor)-perOver(r.RunsAgainst, r.ballsAgainst))*1000) / 1000
+		table = append(table, *r)
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points > table[j].Points
+		}
+		return table[i].TeamName < table[j].TeamName
+	})
+	rank := 1
+	for _, level := range split(table, func(r Row) float64 { return float64(r.Points) }) {
+		for _, group := range breakTie(level, rules.TieBreakers, meetings) {
+			for i := range group {
+				group[i].Rank = rank
+			}
+			rank += len(group)
+		}
+	}
+	return table
+}
+
+// breakTie orders teams level on points by the tie-breakers in turn and
+// returns the groups still level on all of them, which share a rank. Each
+// tie-breaker only splits the teams still level after the ones before it,
+// and head-to-head is the points taken in games among those teams alone, as
+// a mini-table of the tied group.
+func breakTie(group []Row, tieBreakers []string, meetings map[primitive.ObjectID]map[primitive.ObjectID]int) [][]Row {
+	if len(group) < 2 || len(tieBreakers) == 0 {
+		return [][]Row{group}
+	}
+	key := tieBreakKey(tieBreakers[0], group, meetings)
+	sort.SliceStable(group, func(i, j int) bool {
+		return key(group[i]) > key(group[j])
+	})
+	var groups [][]Row
+	for _, sub := range split(group, key) {
+		groups = append(groups, breakTie(sub, tieBreakers[1:], meetings)...)
+	}
+	return groups
+}
+
+// tieBreakKey returns the value teams of group are ranked by, higher first.
+func tieBreakKey(tieBreaker string, group []Row, meetings map[primitive.ObjectID]map[primitive.ObjectID]int) func(Row) float64 {
+	switch tieBreaker {
+	case TieBreakWins:
+		return func(r Row) float64 { return float64(r.Won) }
+	case TieBreakNRR:
+		return func(r Row) float64 { return r.NRR }
+	case TieBreakHeadToHead:
+		mini := make(map[primitive.ObjectID]int, len(group))
+		for _, a := range group {
+			for _, b := range group {
+				mini[a.TeamID] += meetings[a.TeamID][b.TeamID]
+			}
+		}
+		return func(r Row) float64 { return float64(mini[r.TeamID]) }
+	case TieBreakRunsFor:
+		return func(r Row) float64 { return float64(r.RunsFor) }
+	case TieBreakWicketsLost:
+		return func(r Row) float64 { return -float64(r.WicketsLost) }
+	}
+	return func(Row) float64 { return 0 }
+}
+
+// split cuts an ordered slice into runs of rows with the same key. The runs
+// share the slice's backing array.
+func split(rows []Row, key func(Row) float64) [][]Row {
+	var groups [][]Row
+	start := 0
+	for i := 1; i <= len(rows); i++ {
+		if i == len(rows) || key(rows[i]) != key(rows[start]) {
+			groups = append(groups, rows[start:i])
+			start = i
+		}
+	}
+	return groups
+}
+
+// facedBalls is how many balls an innings counts for in net run rate.
+func facedBalls(inn models.ClubInnings, scheduledOvers int) int {
+	if inn.AllOut && scheduledOvers > 0 {
+		return scheduledOvers * 6
+	}
+	balls, _ := ParseOvers(inn.Overs)
+	return balls
+}
+
+func perOver(runs, balls int) float64 {
+	if balls == 0 {
+		return 0
+	}
+	return float64(runs) * 6 / float64(balls)
+}
+
+func overs(balls int) string {
+	return strconv.Itoa(balls/6) + "." + strconv.Itoa(balls%6)
+}
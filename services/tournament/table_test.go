@@ -0,0 +1,172 @@
+package tournament
+
+import (
+	"testing"
+
+	"cric-auction-monolith/pkg/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseOvers(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"20", 120, false},
+		{"12.4", 76, false},
+		{" 0.5 ", 5, false},
+		{"0", 0, false},
+		{"12.6", 0, true},
+		{"12.10", 0, true},
+		{"-1", 0, true},
+		{"", 0, true},
+		{"ten", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOvers(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseOvers(%q) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// league is a set of named teams for building fixtures.
+type league map[string]primitive.ObjectID
+
+func newLeague(names ...string) (league, []Row) {
+	l := make(league, len(names))
+	rows := make([]Row, 0, len(names))
+	for _, n := range names {
+		l[n] = primitive.NewObjectID()
+		rows = append(rows, Row{TeamID: l[n], TeamName: n})
+	}
+	return l, rows
+}
+
+// game is a 20-over fixture between two teams.
+func (l league) game(team1 string, inn1 models.ClubInnings, team2 string, inn2 models.ClubInnings) models.ClubFixture {
+	return models.ClubFixture{
+		Team1ID: l[team1],
+		Team2ID: l[team2],
+		Overs:   20,
+		Result:  &models.ClubResult{Outcome: Decide(inn1, inn2), Team1: inn1, Team2: inn2},
+	}
+}
+
+// win is a game team1 wins by 10 runs, both sides batting 20 overs.
+func (l league) win(team1, team2 string) models.ClubFixture {
+	return l.game(team1, models.ClubInnings{Runs: 150, Overs: "20"}, team2, models.ClubInnings{Runs: 140, Overs: "20"})
+}
+
+func ranks(table []Row) map[string]int {
+	m := make(map[string]int, len(table))
+	for _, r := range table {
+		m[r.TeamName] = r.Rank
+	}
+	return m
+}
+
+func TestTableNRR(t *testing.T) {
+	l, teams := newLeague("A", "B", "C")
+	fixtures := []models.ClubFixture{
+		// B is bowled out in 18.3 overs but counts as facing all 20.
+		l.game("A", models.ClubInnings{Runs: 160, Wickets: 5, Overs: "20"},
+			"B", models.ClubInnings{Runs: 140, Wickets: 10, Overs: "18.3", AllOut: true}),
+		// A chases in 12.4 overs.
+		l.game("C", models.ClubInnings{Runs: 99, Wickets: 9, Overs: "20"},
+			"A", models.ClubInnings{Runs: 100, Wickets: 2, Overs: "12.4"}),
+		// No result: counts for points but not for net run rate.
+		{Team1ID: l["B"], Team2ID: l["C"], Overs: 20, Result: &models.ClubResult{
+			Outcome: models.OutcomeNoResult,
+			Team1:   models.ClubInnings{Runs: 30, Overs: "3"},
+		}},
+	}
+
+	want := map[string]struct {
+		points       int
+		nrr          float64
+		oversFor     string
+		oversAgainst string
+	}{
+		// (160+100)/(196/6) - (140+99)/(240/6)
+		"A": {4, 1.984, "32.4", "40.0"},
+		// 140/20 - 160/20
+		"B": {1, -1.0, "20.0", "20.0"},
+		// 99/20 - 100/(76/6)
+		"C": {1, -2.945, "20.0", "12.4"},
+	}
+
+	table := Table(teams, fixtures, DefaultRules())
+	for _, r := range table {
+		w := want[r.TeamName]
+		if r.Points != w.points || r.NRR != w.nrr || r.OversFor != w.oversFor || r.OversAgainst != w.oversAgainst {
+			t.Errorf("%s: got %d pts, NRR %v, overs %s/%s; want %d pts, NRR %v, overs %s/%s",
+				r.TeamName, r.Points, r.NRR, r.OversFor, r.OversAgainst,
+				w.points, w.nrr, w.oversFor, w.oversAgainst)
+		}
+	}
+	if got := ranks(table); got["A"] != 1 || got["B"] != 2 || got["C"] != 3 {
+		t.Errorf("ranks = %v, want A 1, B 2, C 3", got)
+	}
+}
+
+func TestTableHeadToHead(t *testing.T) {
+	rules := Rules{Win: 2, Tie: 1, NoResult: 1, TieBreakers: []string{TieBreakHeadToHead}}
+
+	tests := []struct {
+		name     string
+		fixtures func(l league) []models.ClubFixture
+		want     map[string]int
+	}{
+		{
+			// A beat B, B beat C, C beat A: level in the mini-table too.
+			name: "cycle shares a rank",
+			fixtures: func(l league) []models.ClubFixture {
+				return []models.ClubFixture{
+					l.win("A", "B"), l.win("B", "C"), l.win("C", "A"),
+					l.win("D", "A"), l.win("D", "B"), l.win("D", "C"),
+				}
+			},
+			want: map[string]int{"D": 1, "A": 2, "B": 2, "C": 2},
+		},
+		{
+			// Only games among A, B and C count, not B's and C's wins
+			// over D.
+			name: "mini-table of the tied teams",
+			fixtures: func(l league) []models.ClubFixture {
+				return []models.ClubFixture{
+					l.win("A", "B"), l.win("A", "C"), l.win("B", "C"),
+					l.win("D", "A"), l.win("B", "D"), l.win("C", "D"), l.win("C", "D"),
+				}
+			},
+			want: map[string]int{"A": 1, "B": 2, "C": 3, "D": 4},
+		},
+		{
+			name: "split group keeps the rest level",
+			fixtures: func(l league) []models.ClubFixture {
+				return []models.ClubFixture{
+					l.win("A", "B"), l.win("A", "C"),
+					l.win("B", "D"), l.win("B", "D"),
+					l.win("C", "D"), l.win("C", "D"),
+					l.win("D", "A"),
+				}
+			},
+			// A, B and C on 4; A took 4 in the mini-table, B and C none.
+			want: map[string]int{"A": 1, "B": 2, "C": 2, "D": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, teams := newLeague("A", "B", "C", "D")
+			got := ranks(Table(teams, tt.fixtures(l), rules))
+			for name, rank := range tt.want {
+				if got[name] != rank {
+					t.Errorf("ranks = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}